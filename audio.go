@@ -0,0 +1,287 @@
+package eventide
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// A source of Opus audio for a voice connection. Audio is passed through to Discord as is, so it must already be
+// encoded as 48kHz stereo Opus
+type AudioSource interface {
+	// Returns the next Opus packet, or io.EOF once the source has ended
+	ReadOpus() ([]byte, error)
+}
+
+// Passed to the track end callback when a track was skipped or stopped before it ended
+var ErrTrackStopped = errors.New("track was stopped")
+
+// An Opus frame of silence, sent after audio stops so that Discord doesn't interpolate the last frame
+var opusSilence = []byte{0xF8, 0xFF, 0xFE}
+
+// Number of silence frames sent when audio stops
+const silenceFrames = 5
+
+// Plays a queue of audio sources over a voice connection
+type AudioPlayer struct {
+	voice *VoiceConnection
+
+	lock       sync.Mutex
+	queue      []AudioSource
+	current    AudioSource
+	paused     bool
+	skip       bool
+	onTrackEnd func(source AudioSource, err error)
+
+	wake   chan struct{}
+	closed chan struct{}
+}
+
+// Creates an audio player for a voice connection, which plays queued sources until it's closed
+func NewAudioPlayer(v *VoiceConnection) *AudioPlayer {
+	p := &AudioPlayer{
+		voice:  v,
+		wake:   make(chan struct{}, 1),
+		closed: make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// Sets a callback run when a track ends, err is nil if the source ended, ErrTrackStopped if it was skipped or stopped,
+// or the error that stopped it playing
+func (p *AudioPlayer) OnTrackEnd(callback func(source AudioSource, err error)) {
+	p.lock.Lock()
+	p.onTrackEnd = callback
+	p.lock.Unlock()
+}
+
+// Adds a source to the end of the queue
+func (p *AudioPlayer) Queue(source AudioSource) {
+	p.lock.Lock()
+	p.queue = append(p.queue, source)
+	p.lock.Unlock()
+	p.notify()
+}
+
+// Returns the source that is playing, or nil if nothing is
+func (p *AudioPlayer) Current() AudioSource {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.current
+}
+
+// Returns the number of sources waiting in the queue
+func (p *AudioPlayer) Len() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.queue)
+}
+
+// Pauses playback, sending silence so the connection stops speaking
+func (p *AudioPlayer) Pause() {
+	p.lock.Lock()
+	p.paused = true
+	p.lock.Unlock()
+}
+
+// Resumes paused playback
+func (p *AudioPlayer) Resume() {
+	p.lock.Lock()
+	p.paused = false
+	p.lock.Unlock()
+	p.notify()
+}
+
+// Whether playback is paused
+func (p *AudioPlayer) Paused() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.paused
+}
+
+// Stops the current track and plays the next one in the queue
+func (p *AudioPlayer) Skip() {
+	p.lock.Lock()
+	if p.current != nil {
+		p.skip = true
+	}
+	p.lock.Unlock()
+	p.notify()
+}
+
+// Stops the current track and clears the queue
+func (p *AudioPlayer) Stop() {
+	p.lock.Lock()
+	p.queue = nil
+	if p.current != nil {
+		p.skip = true
+	}
+	p.lock.Unlock()
+	p.notify()
+}
+
+// Stops playback and the player, it can't be used afterwards
+func (p *AudioPlayer) Close() {
+	p.Stop()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	select {
+	case <-p.closed:
+	default:
+		close(p.closed)
+	}
+}
+
+func (p *AudioPlayer) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (p *AudioPlayer) run() {
+	for {
+		source := p.next()
+		if source == nil {
+			return
+		}
+
+		err := p.play(source)
+		p.silence()
+
+		if closer, ok := source.(io.Closer); ok {
+			closer.Close()
+		}
+
+		p.lock.Lock()
+		p.current = nil
+		p.skip = false
+		callback := p.onTrackEnd
+		p.lock.Unlock()
+
+		if callback != nil {
+			callback(source, err)
+		}
+	}
+}
+
+// Waits for the next source in the queue, returning nil once the player is closed
+func (p *AudioPlayer) next() AudioSource {
+	for {
+		p.lock.Lock()
+		if len(p.queue) > 0 {
+			source := p.queue[0]
+			p.queue = p.queue[1:]
+			p.current = source
+			p.lock.Unlock()
+			return source
+		}
+		p.lock.Unlock()
+
+		select {
+		case <-p.wake:
+		case <-p.closed:
+			return nil
+		}
+	}
+}
+
+func (p *AudioPlayer) play(source AudioSource) error {
+	next := time.Now()
+
+	for {
+		p.lock.Lock()
+		paused, skip := p.paused, p.skip
+		p.lock.Unlock()
+
+		if skip {
+			return ErrTrackStopped
+		}
+		if paused {
+			p.silence()
+			select {
+			case <-p.wake:
+			case <-p.closed:
+				return ErrTrackStopped
+			}
+			next = time.Now()
+			continue
+		}
+
+		frame, err := source.ReadOpus()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if len(frame) == 0 {
+			// empty packets carry no audio and would be sent as a malformed frame
+			continue
+		}
+
+		// frames are sent on a fixed schedule so that delays don't accumulate
+		time.Sleep(time.Until(next))
+		if err := p.voice.WriteOpus(frame); err != nil {
+			return err
+		}
+		next = next.Add(opusPacketDuration(frame))
+	}
+}
+
+// Sends silence frames and stops speaking, if the connection is speaking
+func (p *AudioPlayer) silence() {
+	p.voice.sendLock.Lock()
+	speaking := p.voice.speaking
+	p.voice.sendLock.Unlock()
+	if !speaking {
+		return
+	}
+
+	for i := 0; i < silenceFrames; i++ {
+		if err := p.voice.WriteOpus(opusSilence); err != nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err := p.voice.Speaking(false); err != nil {
+		p.voice.log(LogWarn, "%s", err)
+	}
+}
+
+// Returns the duration of an Opus packet from its TOC byte, defaulting to 20ms
+// https://www.rfc-editor.org/rfc/rfc6716#section-3.1
+func opusPacketDuration(packet []byte) time.Duration {
+	if len(packet) == 0 {
+		return 20 * time.Millisecond
+	}
+
+	config := packet[0] >> 3
+	var frame time.Duration
+	switch {
+	case config < 12:
+		// SILK
+		frame = []time.Duration{10, 20, 40, 60}[config%4] * time.Millisecond
+	case config < 16:
+		// hybrid
+		frame = []time.Duration{10, 20}[config%2] * time.Millisecond
+	default:
+		// CELT
+		frame = []time.Duration{2500, 5000, 10000, 20000}[config%4] * time.Microsecond
+	}
+
+	frames := 1
+	switch packet[0] & 0x03 {
+	case 1, 2:
+		frames = 2
+	case 3:
+		if len(packet) < 2 {
+			return 20 * time.Millisecond
+		}
+		frames = int(packet[1] & 0x3F)
+	}
+
+	return frame * time.Duration(frames)
+}
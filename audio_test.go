@@ -0,0 +1,152 @@
+package eventide
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// An audio source of fixed frames, or an endless stream of 2.5ms frames
+type testAudioSource struct {
+	lock    sync.Mutex
+	frames  [][]byte
+	endless bool
+	reads   int
+}
+
+func (s *testAudioSource) ReadOpus() ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.reads++
+	if s.endless {
+		return []byte{0x80, byte(s.reads)}, nil
+	}
+	if len(s.frames) == 0 {
+		return nil, io.EOF
+	}
+	frame := s.frames[0]
+	s.frames = s.frames[1:]
+	return frame, nil
+}
+
+func (s *testAudioSource) Reads() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.reads
+}
+
+type trackEnd struct {
+	source AudioSource
+	err    error
+}
+
+func newTestAudioPlayer(t *testing.T) (*AudioPlayer, *fakeVoiceServer, chan trackEnd) {
+	s := newFakeVoiceServer(t, false)
+	p := NewAudioPlayer(s.connect(t))
+	t.Cleanup(p.Close)
+
+	ended := make(chan trackEnd, 4)
+	p.OnTrackEnd(func(source AudioSource, err error) {
+		ended <- trackEnd{source, err}
+	})
+
+	return p, s, ended
+}
+
+func waitTrackEnd(t *testing.T, ended chan trackEnd) trackEnd {
+	t.Helper()
+	select {
+	case end := <-ended:
+		return end
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the track to end")
+		return trackEnd{}
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestAudioPlayer(t *testing.T) {
+	p, s, ended := newTestAudioPlayer(t)
+
+	source := &testAudioSource{frames: [][]byte{{0x80, 1}, {}, {0x80, 2}}}
+	p.Queue(source)
+
+	if end := waitTrackEnd(t, ended); end.source != source || end.err != nil {
+		t.Fatalf("expected the track to end without an error, got %v", end.err)
+	}
+
+	// the empty frame is skipped, and silence is sent once the track ends
+	expected := [][]byte{{0x80, 1}, {0x80, 2}, opusSilence}
+	for i, frame := range expected {
+		select {
+		case packet := <-s.packets:
+			opus, err := s.decrypt(packet)
+			if err != nil {
+				t.Fatalf("error decrypting packet %d: %s", i, err)
+			}
+			if !bytes.Equal(opus, frame) {
+				t.Fatalf("packet %d: expected %v, got %v", i, frame, opus)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for packet %d", i)
+		}
+	}
+}
+
+func TestAudioPlayerControls(t *testing.T) {
+	p, _, ended := newTestAudioPlayer(t)
+
+	first := &testAudioSource{endless: true}
+	second := &testAudioSource{endless: true}
+	third := &testAudioSource{endless: true}
+	p.Queue(first)
+	p.Queue(second)
+
+	waitFor(t, "the first track to play", func() bool { return first.Reads() > 0 })
+	if p.Current() != first || p.Len() != 1 {
+		t.Fatalf("expected the first track playing with 1 queued, got %d queued", p.Len())
+	}
+
+	p.Pause()
+	if !p.Paused() {
+		t.Fatal("expected the player to be paused")
+	}
+	time.Sleep(20 * time.Millisecond)
+	paused := first.Reads()
+	time.Sleep(50 * time.Millisecond)
+	if reads := first.Reads(); reads != paused {
+		t.Fatalf("expected no reads while paused, got %d more", reads-paused)
+	}
+
+	p.Resume()
+	waitFor(t, "playback to resume", func() bool { return first.Reads() > paused })
+
+	p.Skip()
+	if end := waitTrackEnd(t, ended); end.source != first || end.err != ErrTrackStopped {
+		t.Fatalf("expected the first track to be stopped, got %v", end.err)
+	}
+	waitFor(t, "the second track to play", func() bool { return p.Current() == second })
+
+	p.Queue(third)
+	p.Stop()
+	if end := waitTrackEnd(t, ended); end.source != second || end.err != ErrTrackStopped {
+		t.Fatalf("expected the second track to be stopped, got %v", end.err)
+	}
+	waitFor(t, "the player to stop", func() bool { return p.Current() == nil })
+	if p.Len() != 0 || third.Reads() != 0 {
+		t.Fatalf("expected the queue to be cleared, got %d queued", p.Len())
+	}
+}
@@ -0,0 +1,124 @@
+package eventide
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Minimum interval between audit log polls made by WatchAuditLog
+const minAuditLogInterval = 5 * time.Second
+
+// Dispatched by WatchAuditLog for each new audit log entry, in the order the entries were created
+type AuditLogEntryEvent struct {
+	// ID of the guild the entry is from
+	GuildID string
+
+	// The new entry
+	Entry *discord.AuditLogEntry
+
+	// The user who made the change, if they were included with the entry
+	User *discord.User
+}
+
+// Options for watching a guild's audit log
+type WatchAuditLogOptions struct {
+	// How often the audit log is checked for new entries, defaults to and can't be less than 5 seconds
+	Interval time.Duration
+
+	// Only watch for entries made by this user ID
+	UserID string
+
+	// Only watch for entries for this type of action
+	ActionType discord.AuditLogEvent
+
+	// Dispatch entries after this entry ID, defaults to the latest entry when the watcher starts
+	After string
+}
+
+// Polls a guild's audit log until the context is cancelled, dispatching an *AuditLogEntryEvent to the client's
+// handlers for each new entry
+func (c *Client) WatchAuditLog(ctx context.Context, guildID string, opts *WatchAuditLogOptions) error {
+	if opts == nil {
+		opts = &WatchAuditLogOptions{}
+	}
+	interval := opts.Interval
+	if interval < minAuditLogInterval {
+		interval = minAuditLogInterval
+	}
+
+	after := opts.After
+	if after == "" {
+		latest, err := c.GetGuildAuditLog(guildID, &discord.GetGuildAuditLog{
+			UserID:     opts.UserID,
+			ActionType: opts.ActionType,
+			Limit:      1,
+		}, WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("error fetching latest audit log entry: %s", err)
+		}
+		if len(latest.AuditLogEntries) > 0 {
+			after = latest.AuditLogEntries[0].ID
+		} else {
+			after = "0"
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		next, err := c.pollAuditLog(ctx, guildID, opts, after)
+		if err != nil {
+			c.log(LogWarn, "error polling audit log for guild %s: %s", guildID, err)
+		}
+		after = next
+	}
+}
+
+// Dispatches the entries after an entry ID, returning the ID of the last entry dispatched
+func (c *Client) pollAuditLog(ctx context.Context, guildID string, opts *WatchAuditLogOptions, after string) (string, error) {
+	for {
+		auditLog, err := c.GetGuildAuditLog(guildID, &discord.GetGuildAuditLog{
+			UserID:     opts.UserID,
+			ActionType: opts.ActionType,
+			After:      after,
+			Limit:      100,
+		}, WithContext(ctx))
+		if err != nil {
+			return after, err
+		}
+
+		entries := auditLog.AuditLogEntries
+		sort.Slice(entries, func(i, j int) bool {
+			return snowflakeLess(entries[i].ID, entries[j].ID)
+		})
+
+		users := make(map[string]*discord.User, len(auditLog.Users))
+		for _, u := range auditLog.Users {
+			users[u.ID] = u
+		}
+
+		for _, e := range entries {
+			c.dispatch(&AuditLogEntryEvent{
+				GuildID: guildID,
+				Entry:   e,
+				User:    users[e.UserID],
+			})
+			after = e.ID
+		}
+
+		if len(entries) < 100 {
+			return after, nil
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package eventide
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+func TestPollAuditLog(t *testing.T) {
+	var (
+		lock    sync.Mutex
+		queries []string
+	)
+	// serves entries 1 to 250 made by alternating users, the oldest after the cursor first but sorted newest first
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		lock.Unlock()
+
+		q := r.URL.Query()
+		after, _ := strconv.Atoi(q.Get("after"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+
+		auditLog := discord.AuditLog{
+			Users: []*discord.User{{ID: "even"}, {ID: "odd"}},
+		}
+		for id := after + 1; id <= 250 && len(auditLog.AuditLogEntries) < limit; id++ {
+			userID := "even"
+			if id%2 == 1 {
+				userID = "odd"
+			}
+			entry := &discord.AuditLogEntry{ID: strconv.Itoa(id), UserID: userID}
+			auditLog.AuditLogEntries = append([]*discord.AuditLogEntry{entry}, auditLog.AuditLogEntries...)
+		}
+		json.NewEncoder(w).Encode(auditLog)
+	}))
+	defer srv.Close()
+
+	c := NewClient(ClientConfig{Token: "token", APIURL: srv.URL})
+
+	var dispatched []int
+	c.AddHandler(func(e *AuditLogEntryEvent) {
+		if e.GuildID != "guild" || e.User == nil || e.User.ID != e.Entry.UserID {
+			t.Errorf("unexpected event for entry %s: %+v", e.Entry.ID, e)
+		}
+		id, _ := strconv.Atoi(e.Entry.ID)
+		dispatched = append(dispatched, id)
+	})
+
+	last, err := c.pollAuditLog(context.Background(), "guild", &WatchAuditLogOptions{}, "20")
+	if err != nil {
+		t.Fatalf("error polling audit log: %s", err)
+	}
+	if last != "250" {
+		t.Fatalf("expected the last entry to be 250, got %s", last)
+	}
+	if !reflect.DeepEqual(dispatched, idRange(21, 250)) {
+		t.Fatalf("expected entries 21 to 250 in ascending order, got %v", dispatched)
+	}
+
+	expected := []string{"after=20&limit=100", "after=120&limit=100", "after=220&limit=100"}
+	if !reflect.DeepEqual(queries, expected) {
+		t.Fatalf("expected queries %v, got %v", expected, queries)
+	}
+
+	// nothing new since the last poll
+	dispatched = nil
+	if last, err = c.pollAuditLog(context.Background(), "guild", &WatchAuditLogOptions{}, last); err != nil || last != "250" {
+		t.Fatalf("expected no change from an empty poll, got %s and %v", last, err)
+	}
+	if len(dispatched) != 0 {
+		t.Fatalf("expected no entries, got %v", dispatched)
+	}
+}
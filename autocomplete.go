@@ -0,0 +1,104 @@
+package eventide
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Interactions must be responded to within 3 seconds, so autocomplete handlers are given slightly less to leave time
+// for the response to be sent
+const autocompleteTimeout = 2500 * time.Millisecond
+
+// Returns up to 25 choices for the focused option of a command, given the partial value the user has typed.
+// The context is cancelled once the interaction's response deadline has passed
+type AutocompleteHandler func(ctx context.Context, i *Interaction, option string, value string) []*discord.ApplicationCommandOptionChoice
+
+type autocompleteKey struct {
+	path   string
+	option string
+}
+
+// Registers an autocomplete handler for an option of the command at the given path, the option must be declared with
+// autocomplete enabled, such as with the `option:"name,autocomplete"` tag
+func (r *CommandRouter) HandleAutocomplete(path string, option string, handler AutocompleteHandler) {
+	r.routesLock.Lock()
+	defer r.routesLock.Unlock()
+
+	if r.autocomplete == nil {
+		r.autocomplete = make(map[autocompleteKey]AutocompleteHandler)
+	}
+	r.autocomplete[autocompleteKey{path: normalisePath(path), option: option}] = handler
+}
+
+// Runs the autocomplete handler for the focused option of an autocomplete interaction, responding with its choices if
+// it returns before the deadline
+func (r *CommandRouter) handleAutocomplete(i *Interaction) {
+	path := commandPath(i.Data)
+	focused := focusedOption(i.Data.Options)
+	if focused == nil {
+		return
+	}
+
+	handler, ok := r.autocompleteHandler(path, focused.Name)
+	if !ok {
+		return
+	}
+
+	value, ok := focused.Value.(string)
+	if !ok && focused.Value != nil {
+		value = fmt.Sprint(focused.Value)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), autocompleteTimeout)
+	defer cancel()
+
+	result := make(chan []*discord.ApplicationCommandOptionChoice, 1)
+	go func() {
+		result <- handler(ctx, i, focused.Name, value)
+	}()
+
+	select {
+	case choices := <-result:
+		if err := i.Autocomplete(choices); err != nil {
+			i.client.log(LogError, "error responding to autocomplete for %s: %s", path, err)
+		}
+	case <-ctx.Done():
+		i.client.log(LogWarn, "autocomplete handler for %s %s missed the response deadline", path, focused.Name)
+	}
+}
+
+// Finds the autocomplete handler for the longest registered prefix of a command path
+func (r *CommandRouter) autocompleteHandler(path string, option string) (AutocompleteHandler, bool) {
+	r.routesLock.RLock()
+	defer r.routesLock.RUnlock()
+
+	for {
+		if handler, ok := r.autocomplete[autocompleteKey{path: path, option: option}]; ok {
+			return handler, true
+		}
+		i := strings.LastIndex(path, " ")
+		if i == -1 {
+			return nil, false
+		}
+		path = path[:i]
+	}
+}
+
+// Returns the option being autocompleted, descending into subcommands
+func focusedOption(options []*discord.ApplicationCommandInteractionDataOption) *discord.ApplicationCommandInteractionDataOption {
+	for _, o := range options {
+		if o.Focused {
+			return o
+		}
+		if isSubCommand(o) {
+			if focused := focusedOption(o.Options); focused != nil {
+				return focused
+			}
+		}
+	}
+	return nil
+}
@@ -21,11 +21,18 @@ type Client struct {
 
 	ws             *websocket.Conn
 	wsLock         sync.RWMutex
+	sendLimiter    *gatewayLimiter
 	http           *http.Client
+	apiURL         string
+	apiVersion     string
+	userAgent      string
+	dialer         *websocket.Dialer
+	gatewayHeaders http.Header
 	lastSequence   int64
 	closeListeners []chan int
 	listenerLock   sync.RWMutex
 	gateway        string
+	gatewayErr     error
 	sessionID      string
 
 	handlers     map[reflect.Type][]Handler
@@ -36,10 +43,26 @@ type Client struct {
 	identifyProperties *discord.IdentifyConnectionProperties
 	intents            discord.Intents
 	compress           bool
-
-	User       *discord.User
-	Guilds     map[string]*discord.Guild
-	guildsLock sync.RWMutex
+	validateMessages   bool
+
+	User        *discord.User
+	Application *discord.Application
+	Guilds      map[string]*discord.Guild
+	guildsLock  sync.RWMutex
+
+	voice     map[string]*VoiceConnection
+	voiceLock sync.RWMutex
+
+	presence     *discord.GatewayPresenceUpdate
+	presenceLock sync.RWMutex
+
+	memberCache        bool
+	chunkGuilds        bool
+	members            map[string]map[string]*discord.GuildMember
+	membersLock        sync.RWMutex
+	memberRequests     map[string]*memberRequest
+	memberRequestsLock sync.Mutex
+	chunkLock          sync.Mutex
 }
 
 // Client configuration
@@ -58,8 +81,54 @@ type ClientConfig struct {
 
 	// Gateway identify properties
 	IdentifyProperties *discord.IdentifyConnectionProperties
+
+	// If enabled, messages are checked against Discord's content and embed limits before being sent
+	ValidateMessages bool
+
+	// Presence set when the client identifies with the gateway
+	Presence *discord.GatewayPresenceUpdate
+
+	// If enabled, guild members are cached from guild creates, member events and member chunks
+	CacheMembers bool
+
+	// If enabled, all members of large guilds are requested into the member cache when the guilds are received. Requires
+	// CacheMembers and the guild members intent
+	ChunkGuilds bool
+
+	// HTTP client used for REST requests, defaults to a client with a 10 second timeout
+	HTTPClient *http.Client
+
+	// Transport used by the default HTTP client, ignored if HTTPClient is set
+	Transport http.RoundTripper
+
+	// Base URL of the REST API without the version, defaults to https://discord.com/api
+	APIURL string
+
+	// Version of the REST API and gateway, defaults to discord.APIVersion
+	APIVersion string
+
+	// User-Agent sent with REST requests, which Discord requires to be in the format DiscordBot ($url, $version).
+	// Defaults to the library's URL and version
+	UserAgent string
+
+	// URL of the gateway to connect to instead of the one returned by the Get Gateway endpoint. The API version and
+	// encoding are added if the URL doesn't have them, and Connect returns an error if it isn't a ws or wss URL
+	GatewayURL string
+
+	// Dialer used to connect to the gateway and voice websockets, for setting a proxy, TLS config or handshake timeout.
+	// Defaults to websocket.DefaultDialer
+	Dialer *websocket.Dialer
+
+	// Extra headers sent with the gateway websocket handshake
+	GatewayHeaders http.Header
 }
 
+// Version of the library, sent in the default User-Agent
+const Version = "0.1.0"
+
+// The API URL that endpoints are built from, which requests replace with the client's configured API URL
+var defaultEndpointAPI = discord.EndpointAPI
+
 func NewClient(cfg ClientConfig) *Client {
 	if !strings.HasPrefix(cfg.Token, "Bot ") {
 		cfg.Token = "Bot " + cfg.Token
@@ -72,20 +141,45 @@ func NewClient(cfg ClientConfig) *Client {
 		}
 	}
 
+	if cfg.Dialer == nil {
+		cfg.Dialer = websocket.DefaultDialer
+	}
+
 	c := &Client{
-		http: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		handlers:     make(map[reflect.Type][]Handler),
-		lastSequence: 0,
+		dialer:         cfg.Dialer,
+		gatewayHeaders: cfg.GatewayHeaders,
+		handlers:       make(map[reflect.Type][]Handler),
+		lastSequence:   0,
+		sendLimiter:    newGatewayLimiter(),
 
 		token:              cfg.Token,
 		logLevel:           cfg.LogLevel,
 		identifyProperties: cfg.IdentifyProperties,
 		intents:            cfg.Intents,
 		compress:           !cfg.DisableCompression,
+		validateMessages:   cfg.ValidateMessages,
+		presence:           cfg.Presence,
+		memberCache:        cfg.CacheMembers,
+		chunkGuilds:        cfg.CacheMembers && cfg.ChunkGuilds,
 
 		Guilds: map[string]*discord.Guild{},
+		voice:  map[string]*VoiceConnection{},
+
+		members:        map[string]map[string]*discord.GuildMember{},
+		memberRequests: map[string]*memberRequest{},
+	}
+	c.configureREST(cfg)
+
+	if cfg.GatewayURL != "" {
+		// the error is returned by Connect rather than falling back to the Get Gateway endpoint
+		c.gateway, c.gatewayErr = gatewayURL(cfg.GatewayURL, c.apiVersion)
+		if c.gatewayErr != nil {
+			c.log(LogError, "invalid gateway url: %s", c.gatewayErr)
+		}
+	}
+
+	if !strings.HasPrefix(c.userAgent, "DiscordBot (") {
+		c.log(LogWarn, "user agent %q isn't in the format DiscordBot ($url, $version), requests may be rejected", c.userAgent)
 	}
 
 	c.registerDefaultHandlers()
@@ -93,6 +187,30 @@ func NewClient(cfg ClientConfig) *Client {
 	return c
 }
 
+// Sets up the HTTP client, API URL and User-Agent used for REST requests, applying their defaults
+func (c *Client) configureREST(cfg ClientConfig) {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: cfg.Transport,
+		}
+	}
+	if cfg.APIURL == "" {
+		cfg.APIURL = "https://discord.com/api"
+	}
+	if cfg.APIVersion == "" {
+		cfg.APIVersion = discord.APIVersion
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = fmt.Sprintf("DiscordBot (https://github.com/thefakequake/eventide, %s)", Version)
+	}
+
+	c.http = cfg.HTTPClient
+	c.apiURL = strings.TrimSuffix(cfg.APIURL, "/") + "/v" + cfg.APIVersion
+	c.apiVersion = cfg.APIVersion
+	c.userAgent = cfg.UserAgent
+}
+
 func (c *Client) Run() error {
 	if err := c.Connect(); err != nil {
 		return fmt.Errorf("error conneting to gateway: %s", err)
@@ -0,0 +1,243 @@
+package eventide
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Derives a chat input command from the option tagged fields of the struct v points to, the same struct that the
+// command's options are bound to. Fields are described with the following tags:
+//
+//	option:"name,required"          the option name, and whether it's required or autocompleted
+//	description:"..."               the option description, required
+//	min:"1" max:"10"                the range permitted for integer and number options
+//	choices:"Red=red,Blue=blue"     choices for string, integer and number options
+//	channel_types:"0,5"             the channel types shown for channel options
+//	name_localizations:"de=farbe"   localized names, and description_localizations for localized descriptions
+//
+// Fields that are pointers to structs become subcommands, or subcommand groups if all of their options are subcommands
+func CommandFromStruct(name string, description string, v any) (*discord.ApplicationCommand, error) {
+	options, err := CommandOptions(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &discord.ApplicationCommand{
+		Type:        discord.ApplicationCommandTypeChatInput,
+		Name:        name,
+		Description: description,
+		Options:     options,
+	}, nil
+}
+
+// Derives application command options from the option tagged fields of the struct v points to, see CommandFromStruct
+func CommandOptions(v any) ([]*discord.ApplicationCommandOption, error) {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("options must be a pointer to a struct, got %T", v)
+	}
+	return commandOptions(t.Elem())
+}
+
+func commandOptions(t reflect.Type) ([]*discord.ApplicationCommandOption, error) {
+	var options []*discord.ApplicationCommandOption
+
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		tag, ok := parseOptionTag(field)
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		o, err := commandOption(field, tag)
+		if err != nil {
+			return nil, OptionError{Option: tag.name, Message: err.Error()}
+		}
+		options = append(options, o)
+	}
+
+	// required options must come before optional ones
+	sort.SliceStable(options, func(a, b int) bool {
+		return options[a].Required && !options[b].Required
+	})
+
+	return options, nil
+}
+
+func commandOption(field reflect.StructField, tag optionTag) (*discord.ApplicationCommandOption, error) {
+	o := &discord.ApplicationCommandOption{
+		Name:        tag.name,
+		Description: field.Tag.Get("description"),
+		Required:    tag.required,
+	}
+	if o.Description == "" {
+		return nil, fmt.Errorf("missing description tag")
+	}
+
+	var err error
+	if o.NameLocalizations, err = parseLocalizations(field.Tag.Get("name_localizations")); err != nil {
+		return nil, err
+	}
+	if o.DescriptionLocalizations, err = parseLocalizations(field.Tag.Get("description_localizations")); err != nil {
+		return nil, err
+	}
+
+	if isSubCommandField(field) {
+		if o.Options, err = commandOptions(field.Type.Elem()); err != nil {
+			return nil, err
+		}
+
+		o.Type = discord.ApplicationCommandOptionTypeSubCommandGroup
+		for _, sub := range o.Options {
+			if sub.Type != discord.ApplicationCommandOptionTypeSubCommand {
+				o.Type = discord.ApplicationCommandOptionTypeSubCommand
+				break
+			}
+		}
+		if len(o.Options) == 0 {
+			o.Type = discord.ApplicationCommandOptionTypeSubCommand
+		}
+		// subcommands can't be required
+		o.Required = false
+
+		return o, nil
+	}
+
+	switch field.Type {
+	case userType, memberType:
+		o.Type = discord.ApplicationCommandOptionTypeUser
+	case channelType:
+		o.Type = discord.ApplicationCommandOptionTypeChannel
+	case roleType:
+		o.Type = discord.ApplicationCommandOptionTypeRole
+	case attachmentType:
+		o.Type = discord.ApplicationCommandOptionTypeAttachment
+	default:
+		switch field.Type.Kind() {
+		case reflect.String:
+			o.Type = discord.ApplicationCommandOptionTypeString
+		case reflect.Bool:
+			o.Type = discord.ApplicationCommandOptionTypeBoolean
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			o.Type = discord.ApplicationCommandOptionTypeInteger
+		case reflect.Float32, reflect.Float64:
+			o.Type = discord.ApplicationCommandOptionTypeNumber
+		default:
+			return nil, fmt.Errorf("unsupported field type %s", field.Type)
+		}
+	}
+
+	numeric := o.Type == discord.ApplicationCommandOptionTypeInteger || o.Type == discord.ApplicationCommandOptionTypeNumber
+
+	for _, key := range []string{"min", "max"} {
+		s, ok := field.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		if !numeric {
+			return nil, fmt.Errorf("%s tag is only valid for integer and number options", key)
+		}
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s tag %q", key, s)
+		}
+		if key == "min" {
+			o.MinValue = &n
+		} else {
+			o.MaxValue = &n
+		}
+	}
+
+	if s, ok := field.Tag.Lookup("choices"); ok {
+		if !numeric && o.Type != discord.ApplicationCommandOptionTypeString {
+			return nil, fmt.Errorf("choices tag is only valid for string, integer and number options")
+		}
+		if o.Choices, err = parseChoices(s, o.Type); err != nil {
+			return nil, err
+		}
+	}
+
+	if tag.autocomplete {
+		if !numeric && o.Type != discord.ApplicationCommandOptionTypeString {
+			return nil, fmt.Errorf("autocomplete is only valid for string, integer and number options")
+		} else if len(o.Choices) > 0 {
+			return nil, fmt.Errorf("autocomplete can't be used with choices")
+		}
+		o.Autocomplete = true
+	}
+
+	if s, ok := field.Tag.Lookup("channel_types"); ok {
+		if o.Type != discord.ApplicationCommandOptionTypeChannel {
+			return nil, fmt.Errorf("channel_types tag is only valid for channel options")
+		}
+		for _, p := range strings.Split(s, ",") {
+			ct, err := strconv.Atoi(strings.TrimSpace(p))
+			if err != nil {
+				return nil, fmt.Errorf("invalid channel type %q", p)
+			}
+			o.ChannelTypes = append(o.ChannelTypes, discord.ChannelType(ct))
+		}
+	}
+
+	return o, nil
+}
+
+// Parses choices in the form "Name=value,Other=other", where a choice without a value uses its name as the value
+func parseChoices(s string, t discord.ApplicationCommandOptionType) ([]*discord.ApplicationCommandOptionChoice, error) {
+	var choices []*discord.ApplicationCommandOptionChoice
+
+	for _, p := range strings.Split(s, ",") {
+		name, value, found := strings.Cut(p, "=")
+		if !found {
+			value = name
+		}
+
+		choice := &discord.ApplicationCommandOptionChoice{Name: name}
+		switch t {
+		case discord.ApplicationCommandOptionTypeInteger:
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid integer choice %q", value)
+			}
+			choice.Value = n
+		case discord.ApplicationCommandOptionTypeNumber:
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number choice %q", value)
+			}
+			choice.Value = n
+		default:
+			choice.Value = value
+		}
+		choices = append(choices, choice)
+	}
+
+	if len(choices) > 25 {
+		return nil, fmt.Errorf("at most 25 choices are allowed")
+	}
+
+	return choices, nil
+}
+
+// Parses localizations in the form "de=name,fr=nom"
+func parseLocalizations(s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	localizations := make(map[string]string)
+	for _, p := range strings.Split(s, ",") {
+		locale, value, found := strings.Cut(p, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid localization %q", p)
+		}
+		localizations[locale] = value
+	}
+
+	return localizations, nil
+}
@@ -0,0 +1,201 @@
+package eventide
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Returns the ID of the client's application, fetching it if the client hasn't received a READY event
+func (c *Client) applicationID(ctx context.Context) (string, error) {
+	c.RLock()
+	app := c.Application
+	c.RUnlock()
+
+	if app != nil && app.ID != "" {
+		return app.ID, nil
+	}
+
+	body, err := c.RequestWithContext(ctx, "GET", discord.EndpointCurrentApplication, nil)
+	if err != nil {
+		return "", fmt.Errorf("error fetching application: %s", err)
+	}
+
+	var application discord.Application
+	if err := json.Unmarshal(body, &application); err != nil {
+		return "", err
+	}
+
+	c.Lock()
+	c.Application = &application
+	c.Unlock()
+
+	return application.ID, nil
+}
+
+// Syncs the registered application commands with the given declared commands, only creating, editing or deleting
+// commands that have changed so that unchanged commands keep their IDs. Global commands are synced if guildID is empty
+func (c *Client) SyncCommands(ctx context.Context, guildID string, commands []*discord.ApplicationCommand) ([]*discord.ApplicationCommand, error) {
+	applicationID, err := c.applicationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// localizations are only returned when requested, and are needed to compare commands
+	getParams := &discord.GetApplicationCommands{WithLocalizations: true}
+
+	var registered []*discord.ApplicationCommand
+	if guildID == "" {
+		registered, err = c.GetGlobalApplicationCommands(applicationID, getParams, WithContext(ctx))
+	} else {
+		registered, err = c.GetGuildApplicationCommands(applicationID, guildID, getParams, WithContext(ctx))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching registered commands: %s", err)
+	}
+
+	existing := make(map[string]*discord.ApplicationCommand, len(registered))
+	for _, cmd := range registered {
+		existing[commandKey(cmd)] = cmd
+	}
+
+	synced := make([]*discord.ApplicationCommand, 0, len(commands))
+	for _, cmd := range commands {
+		key := commandKey(cmd)
+		current, ok := existing[key]
+		delete(existing, key)
+
+		if ok && commandsEqual(cmd, current) {
+			synced = append(synced, current)
+			continue
+		}
+
+		var result *discord.ApplicationCommand
+		if ok {
+			// every field is sent so that removed options, localizations and permissions are cleared
+			dmPermission := commandDmPermission(cmd)
+			params := &discord.EditApplicationCommand{
+				Name:                     cmd.Name,
+				NameLocalizations:        emptyIfNil(cmd.NameLocalizations),
+				Description:              cmd.Description,
+				DescriptionLocalizations: emptyIfNil(cmd.DescriptionLocalizations),
+				Options:                  cmd.Options,
+				DefaultMemberPermissions: &cmd.DefaultMemberPermissions,
+				DmPermission:             &dmPermission,
+				DefaultPermission:        cmd.DefaultPermission,
+			}
+			if params.Options == nil {
+				params.Options = []*discord.ApplicationCommandOption{}
+			}
+			if guildID == "" {
+				result, err = c.EditGlobalApplicationCommand(applicationID, current.ID, params, WithContext(ctx))
+			} else {
+				result, err = c.EditGuildApplicationCommand(applicationID, guildID, current.ID, params, WithContext(ctx))
+			}
+			if err != nil {
+				return synced, fmt.Errorf("error editing command %s: %s", cmd.Name, err)
+			}
+			c.log(LogInfo, "edited application command %s", cmd.Name)
+		} else {
+			params := &discord.CreateApplicationCommand{
+				Name:                     cmd.Name,
+				NameLocalizations:        cmd.NameLocalizations,
+				Description:              cmd.Description,
+				DescriptionLocalizations: cmd.DescriptionLocalizations,
+				Options:                  cmd.Options,
+				DefaultMemberPermissions: cmd.DefaultMemberPermissions,
+				DmPermission:             cmd.DmPermission,
+				DefaultPermission:        cmd.DefaultPermission,
+				Type:                     cmd.Type,
+			}
+			if guildID == "" {
+				result, err = c.CreateGlobalApplicationCommand(applicationID, params, WithContext(ctx))
+			} else {
+				result, err = c.CreateGuildApplicationCommand(applicationID, guildID, params, WithContext(ctx))
+			}
+			if err != nil {
+				return synced, fmt.Errorf("error creating command %s: %s", cmd.Name, err)
+			}
+			c.log(LogInfo, "created application command %s", cmd.Name)
+		}
+
+		synced = append(synced, result)
+	}
+
+	for _, cmd := range existing {
+		if guildID == "" {
+			err = c.DeleteGlobalApplicationCommand(applicationID, cmd.ID, WithContext(ctx))
+		} else {
+			err = c.DeleteGuildApplicationCommand(applicationID, guildID, cmd.ID, WithContext(ctx))
+		}
+		if err != nil {
+			return synced, fmt.Errorf("error deleting command %s: %s", cmd.Name, err)
+		}
+		c.log(LogInfo, "deleted application command %s", cmd.Name)
+	}
+
+	return synced, nil
+}
+
+// Commands are unique by their type and name
+func commandKey(cmd *discord.ApplicationCommand) string {
+	t := cmd.Type
+	if t == 0 {
+		t = discord.ApplicationCommandTypeChatInput
+	}
+	return fmt.Sprintf("%d:%s", t, cmd.Name)
+}
+
+// Compares the user-defined fields of a declared command with a registered one
+func commandsEqual(declared *discord.ApplicationCommand, registered *discord.ApplicationCommand) bool {
+	type fields struct {
+		Name                     string                              `json:"name"`
+		NameLocalizations        map[string]string                   `json:"name_localizations,omitempty"`
+		Description              string                              `json:"description"`
+		DescriptionLocalizations map[string]string                   `json:"description_localizations,omitempty"`
+		Options                  []*discord.ApplicationCommandOption `json:"options,omitempty"`
+		DefaultMemberPermissions string                              `json:"default_member_permissions,omitempty"`
+		DmPermission             bool                                `json:"dm_permission"`
+		DefaultPermission        bool                                `json:"default_permission,omitempty"`
+	}
+
+	// false is omitted when sent, so the default permission is only compared when explicitly enabled
+	project := func(cmd *discord.ApplicationCommand) ([]byte, error) {
+		return json.Marshal(fields{
+			Name:                     cmd.Name,
+			NameLocalizations:        cmd.NameLocalizations,
+			Description:              cmd.Description,
+			DescriptionLocalizations: cmd.DescriptionLocalizations,
+			Options:                  cmd.Options,
+			DefaultMemberPermissions: cmd.DefaultMemberPermissions,
+			DmPermission:             commandDmPermission(cmd),
+			DefaultPermission:        cmd.DefaultPermission && declared.DefaultPermission,
+		})
+	}
+
+	a, err := project(declared)
+	if err != nil {
+		return false
+	}
+	b, err := project(registered)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(a, b)
+}
+
+// Commands are available in DMs unless dm_permission is false
+func commandDmPermission(cmd *discord.ApplicationCommand) bool {
+	return cmd.DmPermission == nil || *cmd.DmPermission
+}
+
+func emptyIfNil(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
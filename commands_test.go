@@ -0,0 +1,165 @@
+package eventide
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Stores global commands the way Discord does, filling in defaults for fields that aren't sent
+type fakeCommandServer struct {
+	lock     sync.Mutex
+	commands map[string]map[string]json.RawMessage
+	nextID   int
+	writes   []string
+}
+
+func (s *fakeCommandServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v"+discord.APIVersion+"/applications/app/commands")
+	id := strings.TrimPrefix(path, "/")
+	if r.Method != http.MethodGet {
+		s.writes = append(s.writes, r.Method+" "+path)
+	}
+
+	var body map[string]json.RawMessage
+	if r.Method == http.MethodPost || r.Method == http.MethodPatch {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if r.URL.Query().Get("with_localizations") != "true" {
+			http.Error(w, "expected with_localizations", http.StatusBadRequest)
+			return
+		}
+		ids := make([]string, 0, len(s.commands))
+		for id := range s.commands {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		commands := make([]map[string]json.RawMessage, 0, len(ids))
+		for _, id := range ids {
+			commands = append(commands, s.commands[id])
+		}
+		json.NewEncoder(w).Encode(commands)
+	case http.MethodPost:
+		s.nextID++
+		cmd := map[string]json.RawMessage{
+			"type":                       json.RawMessage("1"),
+			"default_member_permissions": json.RawMessage("null"),
+			"dm_permission":              json.RawMessage("true"),
+			"default_permission":         json.RawMessage("true"),
+		}
+		for k, v := range body {
+			cmd[k] = v
+		}
+		cmd["id"] = json.RawMessage(fmt.Sprintf(`"%d"`, s.nextID))
+		s.commands[fmt.Sprint(s.nextID)] = cmd
+		json.NewEncoder(w).Encode(cmd)
+	case http.MethodPatch:
+		cmd, ok := s.commands[id]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		for k, v := range body {
+			cmd[k] = v
+		}
+		json.NewEncoder(w).Encode(cmd)
+	case http.MethodDelete:
+		delete(s.commands, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Returns and clears the write requests made since the last call
+func (s *fakeCommandServer) takeWrites() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	writes := s.writes
+	s.writes = nil
+	return writes
+}
+
+func TestSyncCommands(t *testing.T) {
+	s := &fakeCommandServer{commands: map[string]map[string]json.RawMessage{}}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	c := NewClient(ClientConfig{Token: "token", APIURL: srv.URL})
+	c.Application = &discord.Application{ID: "app"}
+	ctx := context.Background()
+
+	noDMs := false
+	commands := []*discord.ApplicationCommand{
+		{
+			Name:                     "ping",
+			Description:              "Pings the bot",
+			NameLocalizations:        map[string]string{"fr": "ping"},
+			DefaultMemberPermissions: "8",
+			DmPermission:             &noDMs,
+			Options: []*discord.ApplicationCommandOption{
+				{Type: discord.ApplicationCommandOptionTypeString, Name: "message", Description: "Message to echo"},
+			},
+		},
+		{Name: "help", Description: "Shows help"},
+	}
+
+	syncCommands := func(expected int) {
+		t.Helper()
+		synced, err := c.SyncCommands(ctx, "", commands)
+		if err != nil {
+			t.Fatalf("error syncing commands: %s", err)
+		}
+		if len(synced) != len(commands) {
+			t.Fatalf("expected %d synced commands, got %d", len(commands), len(synced))
+		}
+		if writes := s.takeWrites(); len(writes) != expected {
+			t.Fatalf("expected %d writes, got %v", expected, writes)
+		}
+	}
+
+	syncCommands(2)
+	// the registered state matches, so nothing is sent
+	syncCommands(0)
+
+	// removing the options, localizations and permissions clears them
+	commands[0].Options = nil
+	commands[0].NameLocalizations = nil
+	commands[0].DefaultMemberPermissions = ""
+	commands[0].DmPermission = nil
+	syncCommands(1)
+	syncCommands(0)
+
+	s.lock.Lock()
+	ping := s.commands["1"]
+	s.lock.Unlock()
+	for field, expected := range map[string]string{
+		"options":                    "[]",
+		"name_localizations":         "{}",
+		"default_member_permissions": "null",
+		"dm_permission":              "true",
+	} {
+		if string(ping[field]) != expected {
+			t.Errorf("expected %s to be %s, got %s", field, expected, ping[field])
+		}
+	}
+
+	commands = commands[:1]
+	syncCommands(1)
+	syncCommands(0)
+}
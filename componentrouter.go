@@ -0,0 +1,228 @@
+package eventide
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Handles a message component or modal submit interaction, params holds the values extracted from the custom ID by the matched pattern
+type ComponentHandler func(i *Interaction, params map[string]string)
+
+// Routes message component and modal submit interactions to handlers by patterns matched against their custom IDs,
+// such as "vote:{pollID}:{choice}", or to handlers registered for a single message
+type ComponentRouter struct {
+	client *Client
+
+	routes   []componentRoute
+	modals   []componentRoute
+	messages map[string]*messageHandler
+	lock     sync.RWMutex
+}
+
+type componentRoute struct {
+	pattern *regexp.Regexp
+	params  []string
+	handler ComponentHandler
+}
+
+// Options for a handler registered for the components of a single message
+type MessageHandlerOptions struct {
+	// How long the handler is active for, it never expires if zero
+	Timeout time.Duration
+
+	// Whether to disable the message's components when the handler expires. Only messages that can be edited through
+	// the channel message endpoint, which excludes ephemeral messages, can have their components disabled
+	DisableComponents bool
+
+	// Called once the handler has expired
+	OnExpire func()
+}
+
+type messageHandler struct {
+	handler   ComponentHandler
+	channelID string
+	opts      MessageHandlerOptions
+	timer     *time.Timer
+}
+
+var componentParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// Creates a component router, register it with Client.AddHandler(router.HandleInteraction). The client is used to
+// disable the components of expired message handlers
+func NewComponentRouter(c *Client) *ComponentRouter {
+	return &ComponentRouter{
+		client:   c,
+		messages: make(map[string]*messageHandler),
+	}
+}
+
+// Registers a handler for components with custom IDs matching the pattern, where each {name} matches a non-empty
+// parameter. Patterns are matched in the order they were registered
+func (r *ComponentRouter) Handle(pattern string, handler ComponentHandler) {
+	route := newComponentRoute(pattern, handler)
+
+	r.lock.Lock()
+	r.routes = append(r.routes, route)
+	r.lock.Unlock()
+}
+
+// Registers a handler for modal submits with custom IDs matching the pattern, the submitted values can be read with
+// ModalValues or BindModal
+func (r *ComponentRouter) HandleModal(pattern string, handler ComponentHandler) {
+	route := newComponentRoute(pattern, handler)
+
+	r.lock.Lock()
+	r.modals = append(r.modals, route)
+	r.lock.Unlock()
+}
+
+func newComponentRoute(pattern string, handler ComponentHandler) componentRoute {
+	route := componentRoute{handler: handler}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	last := 0
+	for _, m := range componentParamPattern.FindAllStringSubmatchIndex(pattern, -1) {
+		expr.WriteString(regexp.QuoteMeta(pattern[last:m[0]]))
+		expr.WriteString("(.+?)")
+		route.params = append(route.params, pattern[m[2]:m[3]])
+		last = m[1]
+	}
+	expr.WriteString(regexp.QuoteMeta(pattern[last:]))
+	expr.WriteString("$")
+	route.pattern = regexp.MustCompile(expr.String())
+
+	return route
+}
+
+// Registers a handler for all components on a message, taking precedence over pattern handlers. The returned function
+// removes the handler without it expiring
+func (r *ComponentRouter) HandleMessage(message *discord.Message, handler ComponentHandler, opts MessageHandlerOptions) func() {
+	h := &messageHandler{
+		handler:   handler,
+		channelID: message.ChannelID,
+		opts:      opts,
+	}
+
+	r.lock.Lock()
+	if existing, ok := r.messages[message.ID]; ok && existing.timer != nil {
+		existing.timer.Stop()
+	}
+	r.messages[message.ID] = h
+	if opts.Timeout > 0 {
+		h.timer = time.AfterFunc(opts.Timeout, func() {
+			r.expire(message.ID, h)
+		})
+	}
+	r.lock.Unlock()
+
+	return func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		if r.messages[message.ID] == h {
+			delete(r.messages, message.ID)
+			if h.timer != nil {
+				h.timer.Stop()
+			}
+		}
+	}
+}
+
+func (r *ComponentRouter) expire(messageID string, h *messageHandler) {
+	r.lock.Lock()
+	if r.messages[messageID] != h {
+		r.lock.Unlock()
+		return
+	}
+	delete(r.messages, messageID)
+	r.lock.Unlock()
+
+	if h.opts.DisableComponents && r.client != nil {
+		if err := r.client.disableMessageComponents(h.channelID, messageID); err != nil {
+			r.client.log(LogError, "error disabling components of message %s: %s", messageID, err)
+		}
+	}
+	if h.opts.OnExpire != nil {
+		h.opts.OnExpire()
+	}
+}
+
+// Runs the handler for a message component or modal submit interaction
+func (r *ComponentRouter) HandleInteraction(i *Interaction) {
+	if i.Data == nil {
+		return
+	}
+
+	var routes []componentRoute
+	switch i.Type {
+	case discord.InteractionTypeMessageComponent:
+		r.lock.RLock()
+		var h *messageHandler
+		if i.Message != nil {
+			h = r.messages[i.Message.ID]
+		}
+		routes = r.routes
+		r.lock.RUnlock()
+
+		if h != nil {
+			h.handler(i, map[string]string{})
+			return
+		}
+	case discord.InteractionTypeModalSubmit:
+		r.lock.RLock()
+		routes = r.modals
+		r.lock.RUnlock()
+	default:
+		return
+	}
+
+	for _, route := range routes {
+		m := route.pattern.FindStringSubmatch(i.Data.CustomID)
+		if m == nil {
+			continue
+		}
+
+		params := make(map[string]string, len(route.params))
+		for n, name := range route.params {
+			params[name] = m[n+1]
+		}
+		route.handler(i, params)
+		return
+	}
+}
+
+// Edits a message so that all of its components are disabled
+func (c *Client) disableMessageComponents(channelID string, messageID string) error {
+	message, err := c.GetChannelMessage(channelID, messageID)
+	if err != nil {
+		return fmt.Errorf("error fetching message: %s", err)
+	}
+	if len(message.Components) == 0 {
+		return nil
+	}
+	DisableComponents(message.Components)
+
+	_, err = c.EditMessage(channelID, messageID, &discord.EditMessage{
+		Components: message.Components,
+	})
+	return err
+}
+
+// Disables all buttons and select menus in the components, including those nested in action rows
+func DisableComponents(components discord.Components) {
+	for _, component := range components {
+		switch c := component.(type) {
+		case *discord.ActionRow:
+			DisableComponents(c.Components)
+		case *discord.Button:
+			c.Disabled = true
+		case *discord.SelectMenu:
+			c.Disabled = true
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package discord
 
+import "encoding/json"
+
 // https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-structure
 type ApplicationCommand struct {
 	// Unique ID of command
@@ -33,7 +35,7 @@ type ApplicationCommand struct {
 	DefaultMemberPermissions string `json:"default_member_permissions,omitempty"`
 
 	// Indicates whether the command is available in DMs with the app, only for globally-scoped commands. By default, commands are visible.
-	DmPermission bool `json:"dm_permission,omitempty"`
+	DmPermission *bool `json:"dm_permission,omitempty"`
 
 	// Not recommended for use as field will soon be deprecated. Indicates whether the command is enabled by default when the app is added to a guild, defaults to true
 	DefaultPermission bool `json:"default_permission,omitempty"`
@@ -81,10 +83,10 @@ type ApplicationCommandOption struct {
 	ChannelTypes []ChannelType `json:"channel_types,omitempty"`
 
 	// If the option is an INTEGER or NUMBER type, the minimum value permitted
-	MinValue float64 `json:"min_value,omitempty"`
+	MinValue *float64 `json:"min_value,omitempty"`
 
 	// If the option is an INTEGER or NUMBER type, the maximum value permitted
-	MaxValue float64 `json:"max_value,omitempty"`
+	MaxValue *float64 `json:"max_value,omitempty"`
 
 	// If autocomplete interactions are enabled for this STRING, INTEGER, or NUMBER type option
 	Autocomplete bool `json:"autocomplete"`
@@ -172,3 +174,102 @@ const (
 	ApplicationCommandPermissionTypeUser
 	ApplicationCommandPermissionTypeChannel
 )
+
+// https://discord.com/developers/docs/interactions/application-commands#get-global-application-commands
+type GetApplicationCommands struct {
+	// Whether to include the full localization dictionaries in the returned commands
+	WithLocalizations bool `json:"with_localizations,omitempty"`
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#create-global-application-command
+type CreateApplicationCommand struct {
+	// Name of command, 1-32 characters
+	Name string `json:"name"`
+
+	// Localization dictionary for the name field. Values follow the same restrictions as name
+	NameLocalizations map[string]string `json:"name_localizations,omitempty"`
+
+	// 1-100 character description for CHAT_INPUT commands
+	Description string `json:"description,omitempty"`
+
+	// Localization dictionary for the description field. Values follow the same restrictions as description
+	DescriptionLocalizations map[string]string `json:"description_localizations,omitempty"`
+
+	// The parameters for the command
+	Options []*ApplicationCommandOption `json:"options,omitempty"`
+
+	// Set of permissions represented as a bit set
+	DefaultMemberPermissions string `json:"default_member_permissions,omitempty"`
+
+	// Indicates whether the command is available in DMs with the app, only for globally-scoped commands. By default, commands are visible.
+	DmPermission *bool `json:"dm_permission,omitempty"`
+
+	// Replaced by default_member_permissions and will be deprecated in the future. Indicates whether the command is enabled by default when the app is added to a guild
+	DefaultPermission bool `json:"default_permission,omitempty"`
+
+	// Type of command, defaults 1 if not set
+	Type ApplicationCommandType `json:"type,omitempty"`
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#edit-global-application-command
+type EditApplicationCommand struct {
+	// Name of command, 1-32 characters
+	Name string `json:"name,omitempty"`
+
+	// Localization dictionary for the name field. Values follow the same restrictions as name. An empty non-nil map
+	// removes the localizations
+	NameLocalizations map[string]string `json:"name_localizations,omitempty"`
+
+	// 1-100 character description
+	Description string `json:"description,omitempty"`
+
+	// Localization dictionary for the description field. Values follow the same restrictions as description. An empty
+	// non-nil map removes the localizations
+	DescriptionLocalizations map[string]string `json:"description_localizations,omitempty"`
+
+	// The parameters for the command, an empty non-nil slice removes all options
+	Options []*ApplicationCommandOption `json:"options,omitempty"`
+
+	// Set of permissions represented as a bit set, an empty string resets the command to being usable by everyone
+	DefaultMemberPermissions *string `json:"default_member_permissions,omitempty"`
+
+	// Indicates whether the command is available in DMs with the app, only for globally-scoped commands. By default, commands are visible.
+	DmPermission *bool `json:"dm_permission,omitempty"`
+
+	// Replaced by default_member_permissions and will be deprecated in the future. Indicates whether the command is enabled by default when the app is added to a guild
+	DefaultPermission bool `json:"default_permission,omitempty"`
+}
+
+// Sends empty localizations and options so they can be removed, and an empty DefaultMemberPermissions as null
+func (e EditApplicationCommand) MarshalJSON() ([]byte, error) {
+	type edit EditApplicationCommand
+	data, err := json.Marshal(edit(e))
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if e.NameLocalizations != nil {
+		fields["name_localizations"], _ = json.Marshal(e.NameLocalizations)
+	}
+	if e.DescriptionLocalizations != nil {
+		fields["description_localizations"], _ = json.Marshal(e.DescriptionLocalizations)
+	}
+	if e.Options != nil {
+		fields["options"], _ = json.Marshal(e.Options)
+	}
+	if e.DefaultMemberPermissions != nil && *e.DefaultMemberPermissions == "" {
+		fields["default_member_permissions"] = json.RawMessage("null")
+	}
+
+	return json.Marshal(fields)
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#edit-application-command-permissions
+type EditApplicationCommandPermissions struct {
+	// Permissions for the command in the guild
+	Permissions []*ApplicationCommandPermissions `json:"permissions"`
+}
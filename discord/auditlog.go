@@ -132,12 +132,31 @@ type OptionalAuditEntryInfo struct {
 
 // https://discord.com/developers/docs/resources/audit-log#audit-log-change-object-audit-log-change-structure
 type AuditLogChange struct {
-	// New value of the key
+	// New value of the key, decoded to a type depending on the key such as string, int, bool, []*Role, []*Overwrite or
+	// Timestamp
 	NewValue interface{} `json:"new_value,omitempty"`
 
-	// Old value of the key
+	// Old value of the key, decoded in the same way as NewValue
 	OldValue interface{} `json:"old_value,omitempty"`
 
 	// Name of the changed entity, with a few exceptions
 	Key string `json:"key"`
 }
+
+// https://discord.com/developers/docs/resources/audit-log#get-guild-audit-log-query-string-params
+type GetGuildAuditLog struct {
+	// Entries from a specific user ID
+	UserID string `json:"user_id,omitempty"`
+
+	// Entries for a specific audit log event
+	ActionType AuditLogEvent `json:"action_type,omitempty"`
+
+	// Entries that preceded a specific audit log entry ID
+	Before string `json:"before,omitempty"`
+
+	// Entries that succeeded a specific audit log entry ID
+	After string `json:"after,omitempty"`
+
+	// Maximum number of entries (between 1-100) to return, defaults to 50
+	Limit int `json:"limit,omitempty"`
+}
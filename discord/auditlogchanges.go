@@ -0,0 +1,148 @@
+package discord
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Returns a pointer to a value of the type that a change key's values are decoded to, or nil if the key's type isn't
+// known
+// https://discord.com/developers/docs/resources/audit-log#audit-log-change-object-audit-log-change-exceptions
+func auditLogChangeValue(key string) interface{} {
+	switch key {
+	case "$add", "$remove":
+		return &[]*Role{}
+	case "permission_overwrites":
+		return &[]*Overwrite{}
+	case "communication_disabled_until":
+		return &Timestamp{}
+	case "afk_timeout", "auto_archive_duration", "bitrate", "color", "default_auto_archive_duration",
+		"default_message_notifications", "default_thread_rate_limit_per_user", "entity_type", "event_type",
+		"explicit_content_filter", "flags", "format_type", "max_age", "max_uses", "mfa_level", "position",
+		"privacy_level", "prune_delete_days", "rate_limit_per_user", "status", "system_channel_flags", "trigger_type",
+		"type", "user_limit", "uses", "verification_level", "video_quality_mode":
+		return new(int)
+	case "archived", "available", "deaf", "enable_emoticons", "enabled", "hoist", "invitable", "locked", "mentionable",
+		"mute", "nsfw", "premium_progress_bar_enabled", "temporary", "widget_enabled":
+		return new(bool)
+	case "afk_channel_id", "allow", "application_id", "asset", "avatar_hash", "banner_hash", "channel_id", "code", "deny",
+		"description", "discovery_splash_hash", "icon_hash", "id", "image_hash", "inviter_id", "location", "name", "nick",
+		"owner_id", "permissions", "preferred_locale", "public_updates_channel_id", "region", "rtc_region",
+		"rules_channel_id", "splash_hash", "system_channel_id", "tags", "topic", "unicode_emoji", "vanity_url_code",
+		"widget_channel_id":
+		return new(string)
+	}
+	return nil
+}
+
+// Decodes a change value to the key's type, falling back to a generic value if the key isn't known or the value
+// doesn't match the expected type
+func decodeAuditLogValue(key string, data json.RawMessage) (interface{}, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	if v := auditLogChangeValue(key); v != nil {
+		if err := json.Unmarshal(data, v); err == nil {
+			switch v := v.(type) {
+			case *[]*Role:
+				return *v, nil
+			case *[]*Overwrite:
+				return *v, nil
+			case *Timestamp:
+				return *v, nil
+			case *int:
+				return *v, nil
+			case *bool:
+				return *v, nil
+			case *string:
+				return *v, nil
+			}
+		}
+	}
+
+	var v interface{}
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+func (c *AuditLogChange) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		NewValue json.RawMessage `json:"new_value"`
+		OldValue json.RawMessage `json:"old_value"`
+		Key      string          `json:"key"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	c.Key = raw.Key
+	if c.NewValue, err = decodeAuditLogValue(raw.Key, raw.NewValue); err != nil {
+		return err
+	}
+	if c.OldValue, err = decodeAuditLogValue(raw.Key, raw.OldValue); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Describes the change, such as name: "general" -> "chat"
+func (c *AuditLogChange) String() string {
+	switch c.Key {
+	case "$add":
+		return "added roles: " + formatAuditLogValue(c.NewValue)
+	case "$remove":
+		return "removed roles: " + formatAuditLogValue(c.NewValue)
+	}
+
+	switch {
+	case c.OldValue == nil && c.NewValue == nil:
+		return c.Key + ": changed"
+	case c.OldValue == nil:
+		return fmt.Sprintf("%s: set to %s", c.Key, formatAuditLogValue(c.NewValue))
+	case c.NewValue == nil:
+		return fmt.Sprintf("%s: removed %s", c.Key, formatAuditLogValue(c.OldValue))
+	}
+	return fmt.Sprintf("%s: %s -> %s", c.Key, formatAuditLogValue(c.OldValue), formatAuditLogValue(c.NewValue))
+}
+
+func formatAuditLogValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case Timestamp:
+		if v.IsZero() {
+			return "none"
+		}
+		return v.Format(time.RFC3339)
+	case []*Role:
+		names := make([]string, len(v))
+		for i, r := range v {
+			names[i] = fmt.Sprintf("%s (%s)", r.Name, r.ID)
+		}
+		return strings.Join(names, ", ")
+	case []*Overwrite:
+		overwrites := make([]string, len(v))
+		for i, o := range v {
+			kind := "role"
+			if o.Type == OverwriteTypeMember {
+				kind = "member"
+			}
+			overwrites[i] = fmt.Sprintf("%s %s allow %s deny %s", kind, o.ID, o.Allow, o.Deny)
+		}
+		return "[" + strings.Join(overwrites, ", ") + "]"
+	}
+	return fmt.Sprint(v)
+}
+
+// Describes the entry's changes, one per line
+func (e *AuditLogEntry) Diff() string {
+	lines := make([]string, len(e.Changes))
+	for i, c := range e.Changes {
+		lines[i] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,98 @@
+package discord
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecodeAuditLogValue(t *testing.T) {
+	tests := []struct {
+		key      string
+		data     string
+		expected interface{}
+	}{
+		{key: "bitrate", data: `64000`, expected: 64000},
+		{key: "nsfw", data: `true`, expected: true},
+		{key: "name", data: `"general"`, expected: "general"},
+		{key: "name", data: `null`, expected: nil},
+		{
+			key:      "$add",
+			data:     `[{"id":"1","name":"Mod"}]`,
+			expected: []*Role{{ID: "1", Name: "Mod"}},
+		},
+		{
+			key:      "$remove",
+			data:     `[{"id":"2","name":"Muted"}]`,
+			expected: []*Role{{ID: "2", Name: "Muted"}},
+		},
+		{
+			key:      "permission_overwrites",
+			data:     `[{"id":"3","type":1,"allow":"1024","deny":"0"}]`,
+			expected: []*Overwrite{{ID: "3", Type: OverwriteTypeMember, Allow: "1024", Deny: "0"}},
+		},
+		{
+			key:      "communication_disabled_until",
+			data:     `"2022-06-01T12:00:00+00:00"`,
+			expected: Timestamp{time.Date(2022, 6, 1, 12, 0, 0, 0, time.FixedZone("", 0))},
+		},
+		// an integration's type is a string rather than the int used by channels and webhooks
+		{key: "type", data: `"twitch"`, expected: "twitch"},
+		{key: "type", data: `0`, expected: 0},
+		// unknown keys are decoded generically
+		{key: "unknown_key", data: `{"a":1}`, expected: map[string]interface{}{"a": float64(1)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key+" "+test.data, func(t *testing.T) {
+			v, err := decodeAuditLogValue(test.key, json.RawMessage(test.data))
+			if err != nil {
+				t.Fatalf("error decoding value: %s", err)
+			}
+			if ts, ok := v.(Timestamp); ok {
+				if !ts.Equal(test.expected.(Timestamp).Time) {
+					t.Fatalf("expected %s, got %s", test.expected, ts)
+				}
+				return
+			}
+			if !reflect.DeepEqual(v, test.expected) {
+				t.Fatalf("expected %#v, got %#v", test.expected, v)
+			}
+		})
+	}
+}
+
+func TestAuditLogEntryDiff(t *testing.T) {
+	var entry AuditLogEntry
+	err := json.Unmarshal([]byte(`{
+		"id": "1",
+		"changes": [
+			{"key": "name", "old_value": "general", "new_value": "chat"},
+			{"key": "topic", "new_value": "hello"},
+			{"key": "nsfw", "old_value": true},
+			{"key": "$add", "new_value": [{"id": "1", "name": "Mod"}, {"id": "2", "name": "Admin"}]},
+			{"key": "$remove", "new_value": [{"id": "3", "name": "Muted"}]},
+			{"key": "permission_overwrites", "new_value": [{"id": "4", "type": 0, "allow": "8", "deny": "0"}]},
+			{"key": "communication_disabled_until", "old_value": null, "new_value": "2022-06-01T12:00:00Z"},
+			{"key": "type", "old_value": "twitch", "new_value": "youtube"},
+			{"key": "avatar_hash"}
+		]
+	}`), &entry)
+	if err != nil {
+		t.Fatalf("error decoding entry: %s", err)
+	}
+
+	expected := `name: "general" -> "chat"
+topic: set to "hello"
+nsfw: removed true
+added roles: Mod (1), Admin (2)
+removed roles: Muted (3)
+permission_overwrites: set to [role 4 allow 8 deny 0]
+communication_disabled_until: set to 2022-06-01T12:00:00Z
+type: "twitch" -> "youtube"
+avatar_hash: changed`
+	if diff := entry.Diff(); diff != expected {
+		t.Fatalf("expected diff:\n%s\ngot:\n%s", expected, diff)
+	}
+}
@@ -1,9 +1,5 @@
 package discord
 
-import (
-	"time"
-)
-
 // https://discord.com/developers/docs/resources/channel#channel-object-channel-structure
 type Channel struct {
 	// The ID of this channel
@@ -58,7 +54,7 @@ type Channel struct {
 	ParentID string `json:"parent_id,omitempty"`
 
 	// When the last pinned message was pinned. This may be null in events such as GUILD_CREATE when a message is not pinned.
-	LastPinTimestamp time.Time `json:"last_pin_timestamp,omitempty"`
+	LastPinTimestamp Timestamp `json:"last_pin_timestamp,omitempty"`
 
 	// Voice region ID for the voice channel, automatic when set to null
 	RTCRegion string `json:"rtc_region,omitempty"`
@@ -142,10 +138,10 @@ type Message struct {
 	Content string `json:"content"`
 
 	// When this message was sent
-	Timestamp time.Time `json:"timestamp"`
+	Timestamp Timestamp `json:"timestamp"`
 
 	// When this message was edited (or null if never)
-	EditedTimestamp time.Time `json:"edited_timestamp"`
+	EditedTimestamp Timestamp `json:"edited_timestamp"`
 
 	// Whether this was a TTS message
 	TTS bool `json:"tts"`
@@ -208,7 +204,7 @@ type Message struct {
 	Thread *Channel `json:"thread,omitempty"`
 
 	// Sent if the message contains components like buttons, action rows, or other interactive components
-	// Components []*MessageComponent `json:"components,omitempty"`
+	Components Components `json:"components,omitempty"`
 
 	// Sent if the message contains stickers
 	StickerItems []*StickerItem `json:"sticker_items,omitempty"`
@@ -359,7 +355,7 @@ type ThreadMetadata struct {
 	AutoArchiveDuration int `json:"auto_archive_duration"`
 
 	// Timestamp when the thread's archive status was last changed, used for calculating recent activity
-	ArchiveTimestamp time.Time `json:"archive_timestamp"`
+	ArchiveTimestamp Timestamp `json:"archive_timestamp"`
 
 	// Whether the thread is locked; when a thread is locked, only users with MANAGE_THREADS can unarchive it
 	Locked bool `json:"locked"`
@@ -368,7 +364,7 @@ type ThreadMetadata struct {
 	Invitable bool `json:"invitable,omitempty"`
 
 	// Timestamp when the thread was created; only populated for threads created after 2022-01-09
-	CreateTimestamp time.Time `json:"create_timestamp,omitempty"`
+	CreateTimestamp Timestamp `json:"create_timestamp,omitempty"`
 }
 
 // https://discord.com/developers/docs/resources/channel#thread-member-object-thread-member-structure
@@ -380,7 +376,7 @@ type ThreadMember struct {
 	UserID string `json:"user_id"`
 
 	// The time the current user last joined the thread
-	JoinTimestamp time.Time `json:"join_timestamp"`
+	JoinTimestamp Timestamp `json:"join_timestamp"`
 
 	// Any user-thread settings, currently only used for notifications
 	Flags int `json:"flags"`
@@ -401,7 +397,7 @@ type Embed struct {
 	URL string `json:"url,omitempty"`
 
 	// Timestamp of embed content
-	Timestamp time.Time `json:"timestamp,omitempty"`
+	Timestamp *Timestamp `json:"timestamp,omitempty"`
 
 	// Color code of the embed
 	Color int `json:"color,omitempty"`
@@ -688,7 +684,7 @@ type CreateMessage struct {
 	MessageReference *MessageReference `json:"message_reference,omitempty"`
 
 	// Components to include with the message
-	// Components []*MessageComponent `json:"components"`
+	Components Components `json:"components,omitempty"`
 
 	// IDs of up to 3 stickers in the server to send in the message
 	StickerIDs []string `json:"sticker_ids,omitempty"`
@@ -730,7 +726,7 @@ type EditMessage struct {
 	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
 
 	// Components to include with the message
-	// Components []*MessageComponent `json:"components,omitempty"`
+	Components Components `json:"components,omitempty"`
 
 	// Contents of the file being sent/edited
 	// Files []*File `json:"files,omitempty"`
@@ -852,7 +848,7 @@ type ForumThreadMessageParams struct {
 	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
 
 	// Components to include with the message
-	// Components []*MessageComponent `json:"components,omitempty"`
+	Components Components `json:"components,omitempty"`
 
 	// IDs of up to 3 stickers in the server to send in the message
 	StickerIDs []string `json:"sticker_ids"`
@@ -873,7 +869,7 @@ type ForumThreadMessageParams struct {
 // https://discord.com/developers/docs/resources/channel#list-public-archived-threads
 type ListArchivedThreads struct {
 	// Returns threads before this timestamp
-	Before time.Time `json:"before,omitempty"`
+	Before *Timestamp `json:"before,omitempty"`
 
 	// Optional maximum number of threads to return
 	Limit int `json:"limit,omitempty"`
@@ -0,0 +1,115 @@
+package discord
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// https://discord.com/developers/docs/resources/channel#embed-object-embed-limits
+const (
+	EmbedTitleLimit       = 256
+	EmbedDescriptionLimit = 4096
+	EmbedFieldsLimit      = 25
+	EmbedFieldNameLimit   = 256
+	EmbedFieldValueLimit  = 1024
+	EmbedFooterTextLimit  = 2048
+	EmbedAuthorNameLimit  = 256
+
+	// The combined length of the title, description, field names and values, footer text and author name of all
+	// embeds in a message
+	EmbedTotalLimit = 6000
+
+	MessageContentLimit = 2000
+	MessageEmbedsLimit  = 10
+)
+
+// The number of characters in the embed that count towards EmbedTotalLimit
+func (e *Embed) Length() int {
+	n := utf8.RuneCountInString(e.Title) + utf8.RuneCountInString(e.Description)
+	for _, f := range e.Fields {
+		n += utf8.RuneCountInString(f.Name) + utf8.RuneCountInString(f.Value)
+	}
+	if e.Footer != nil {
+		n += utf8.RuneCountInString(e.Footer.Text)
+	}
+	if e.Author != nil {
+		n += utf8.RuneCountInString(e.Author.Name)
+	}
+	return n
+}
+
+// Checks the embed against Discord's embed limits
+func (e *Embed) Validate() error {
+	if err := checkLength("title", e.Title, EmbedTitleLimit); err != nil {
+		return err
+	}
+	if err := checkLength("description", e.Description, EmbedDescriptionLimit); err != nil {
+		return err
+	}
+
+	if len(e.Fields) > EmbedFieldsLimit {
+		return fmt.Errorf("embed must have at most %d fields, got %d", EmbedFieldsLimit, len(e.Fields))
+	}
+	for n, f := range e.Fields {
+		if f.Name == "" || f.Value == "" {
+			return fmt.Errorf("embed field %d must have a name and value", n)
+		}
+		if err := checkLength(fmt.Sprintf("field %d name", n), f.Name, EmbedFieldNameLimit); err != nil {
+			return err
+		}
+		if err := checkLength(fmt.Sprintf("field %d value", n), f.Value, EmbedFieldValueLimit); err != nil {
+			return err
+		}
+	}
+
+	if e.Footer != nil {
+		if err := checkLength("footer text", e.Footer.Text, EmbedFooterTextLimit); err != nil {
+			return err
+		}
+	}
+	if e.Author != nil {
+		if err := checkLength("author name", e.Author.Name, EmbedAuthorNameLimit); err != nil {
+			return err
+		}
+	}
+
+	if n := e.Length(); n > EmbedTotalLimit {
+		return fmt.Errorf("embed must have at most %d characters in total, got %d", EmbedTotalLimit, n)
+	}
+
+	return nil
+}
+
+// Checks the message's content and embeds against Discord's limits
+func (m *CreateMessage) Validate() error {
+	if n := utf8.RuneCountInString(m.Content); n > MessageContentLimit {
+		return fmt.Errorf("message content must be at most %d characters, got %d", MessageContentLimit, n)
+	}
+	return validateEmbeds(m.Embeds)
+}
+
+func validateEmbeds(embeds []*Embed) error {
+	if len(embeds) > MessageEmbedsLimit {
+		return fmt.Errorf("message must have at most %d embeds, got %d", MessageEmbedsLimit, len(embeds))
+	}
+
+	total := 0
+	for n, e := range embeds {
+		if err := e.Validate(); err != nil {
+			return fmt.Errorf("invalid embed %d: %s", n, err)
+		}
+		total += e.Length()
+	}
+	if total > EmbedTotalLimit {
+		return fmt.Errorf("message embeds must have at most %d characters in total, got %d", EmbedTotalLimit, total)
+	}
+
+	return nil
+}
+
+func checkLength(name string, s string, limit int) error {
+	if n := utf8.RuneCountInString(s); n > limit {
+		return fmt.Errorf("embed %s must be at most %d characters, got %d", name, limit, n)
+	}
+	return nil
+}
@@ -8,7 +8,32 @@ var (
 	EndpointGuilds = EndpointAPI + "/guilds"
 	EndpointGuild  = func(gID string) string { return EndpointGuilds + "/" + gID }
 
-	EndpointGuildAuditLog = func(gID string) string { return EndpointGuild(gID) + "/audit-logs" }
+	EndpointGuildAuditLog       = func(gID string) string { return EndpointGuild(gID) + "/audit-logs" }
+	EndpointGuildPreview        = func(gID string) string { return EndpointGuild(gID) + "/preview" }
+	EndpointGuildChannels       = func(gID string) string { return EndpointGuild(gID) + "/channels" }
+	EndpointGuildActiveThreads  = func(gID string) string { return EndpointGuild(gID) + "/threads/active" }
+	EndpointGuildMembers        = func(gID string) string { return EndpointGuild(gID) + "/members" }
+	EndpointGuildMembersSearch  = func(gID string) string { return EndpointGuildMembers(gID) + "/search" }
+	EndpointGuildMember         = func(gID, uID string) string { return EndpointGuildMembers(gID) + "/" + uID }
+	EndpointGuildMemberSelf     = func(gID string) string { return EndpointGuildMembers(gID) + "/@me" }
+	EndpointGuildMemberRole     = func(gID, uID, rID string) string { return EndpointGuildMember(gID, uID) + "/roles/" + rID }
+	EndpointGuildBans           = func(gID string) string { return EndpointGuild(gID) + "/bans" }
+	EndpointGuildBan            = func(gID, uID string) string { return EndpointGuildBans(gID) + "/" + uID }
+	EndpointGuildRoles          = func(gID string) string { return EndpointGuild(gID) + "/roles" }
+	EndpointGuildRole           = func(gID, rID string) string { return EndpointGuildRoles(gID) + "/" + rID }
+	EndpointGuildMFA            = func(gID string) string { return EndpointGuild(gID) + "/mfa" }
+	EndpointGuildPrune          = func(gID string) string { return EndpointGuild(gID) + "/prune" }
+	EndpointGuildVoiceRegions   = func(gID string) string { return EndpointGuild(gID) + "/regions" }
+	EndpointGuildInvites        = func(gID string) string { return EndpointGuild(gID) + "/invites" }
+	EndpointGuildIntegrations   = func(gID string) string { return EndpointGuild(gID) + "/integrations" }
+	EndpointGuildIntegration    = func(gID, iID string) string { return EndpointGuildIntegrations(gID) + "/" + iID }
+	EndpointGuildWidgetSettings = func(gID string) string { return EndpointGuild(gID) + "/widget" }
+	EndpointGuildWidget         = func(gID string) string { return EndpointGuild(gID) + "/widget.json" }
+	EndpointGuildVanityURL      = func(gID string) string { return EndpointGuild(gID) + "/vanity-url" }
+	EndpointGuildWelcomeScreen  = func(gID string) string { return EndpointGuild(gID) + "/welcome-screen" }
+	EndpointGuildVoiceStateSelf = func(gID string) string { return EndpointGuild(gID) + "/voice-states/@me" }
+	EndpointGuildVoiceState     = func(gID, uID string) string { return EndpointGuild(gID) + "/voice-states/" + uID }
+	EndpointGuildWebhooks       = func(gID string) string { return EndpointGuild(gID) + "/webhooks" }
 
 	EndpointChannels               = EndpointAPI + "/channels"
 	EndpointChannel                = func(cID string) string { return EndpointChannels + "/" + cID }
@@ -35,7 +60,28 @@ var (
 	EndpointArchivedThreadsPrivate = func(cID string) string { return EndpointArchivedThreads(cID) + "/private" }
 	EndpointArchivedThreadsPublic  = func(cID string) string { return EndpointArchivedThreads(cID) + "/public" }
 	EndpointJoinedArchivedThreads  = func(cID string) string { return EndpointChannel(cID) + "/users/@me/threads/archived/private" }
+	EndpointChannelWebhooks        = func(cID string) string { return EndpointChannel(cID) + "/webhooks" }
 
 	EndpointGuildEmojis = func(gID string) string { return EndpointGuild(gID) + "/emojis" }
 	EndpointGuildEmoji  = func(gID, eID string) string { return EndpointGuildEmojis(gID) + "/" + eID }
+
+	EndpointWebhooks         = EndpointAPI + "/webhooks"
+	EndpointWebhook          = func(wID string) string { return EndpointWebhooks + "/" + wID }
+	EndpointWebhookWithToken = func(wID, token string) string { return EndpointWebhook(wID) + "/" + token }
+	EndpointWebhookMessage   = func(wID, token, mID string) string { return EndpointWebhookWithToken(wID, token) + "/messages/" + mID }
+
+	EndpointApplications             = EndpointAPI + "/applications"
+	EndpointApplication              = func(aID string) string { return EndpointApplications + "/" + aID }
+	EndpointGlobalCommands           = func(aID string) string { return EndpointApplication(aID) + "/commands" }
+	EndpointGlobalCommand            = func(aID, cID string) string { return EndpointGlobalCommands(aID) + "/" + cID }
+	EndpointGuildCommands            = func(aID, gID string) string { return EndpointApplication(aID) + "/guilds/" + gID + "/commands" }
+	EndpointGuildCommand             = func(aID, gID, cID string) string { return EndpointGuildCommands(aID, gID) + "/" + cID }
+	EndpointGuildCommandsPermissions = func(aID, gID string) string { return EndpointGuildCommands(aID, gID) + "/permissions" }
+	EndpointCommandPermissions       = func(aID, gID, cID string) string { return EndpointGuildCommand(aID, gID, cID) + "/permissions" }
+
+	EndpointOAuth2             = EndpointAPI + "/oauth2"
+	EndpointCurrentApplication = EndpointOAuth2 + "/applications/@me"
+
+	EndpointInteractions        = EndpointAPI + "/interactions"
+	EndpointInteractionCallback = func(iID, token string) string { return EndpointInteractions + "/" + iID + "/" + token + "/callback" }
 )
@@ -1,6 +1,6 @@
 package discord
 
-import "time"
+import "encoding/json"
 
 type Event interface {
 	EventType() string
@@ -149,7 +149,7 @@ type ChannelPinsUpdateEvent struct {
 	ChannelID string `json:"channel_id"`
 
 	// The time at which the most recent pinned message was pinned
-	LastPinTimestamp time.Time `json:"last_pin_timestamp,omitempty"`
+	LastPinTimestamp Timestamp `json:"last_pin_timestamp,omitempty"`
 }
 
 func (c *ChannelPinsUpdateEvent) EventType() string { return "CHANNEL_PINS_UPDATE" }
@@ -159,7 +159,7 @@ type GuildCreateEvent struct {
 	*Guild
 
 	// When this guild was joined at
-	JoinedAt time.Time `json:"joined_at"`
+	JoinedAt Timestamp `json:"joined_at"`
 
 	// True if this is considered a large guild
 	Large bool `json:"large"`
@@ -174,7 +174,7 @@ type GuildCreateEvent struct {
 	VoiceStates []*VoiceState `json:"voice_states"`
 
 	// Users in the guild
-	Members []*GuildMember `json:"member"`
+	Members []*GuildMember `json:"members"`
 
 	// Channels in the guild
 	Channels []*Channel `json:"channels"`
@@ -292,28 +292,77 @@ type GuildMemberUpdateEvent struct {
 	// The user
 	User *User `json:"user"`
 
-	// Nickname of the user in the guild
-	Nick string `json:"nick,omitempty"`
+	// Nickname of the user in the guild, nil if it wasn't sent and empty if it was removed
+	Nick *string `json:"nick,omitempty"`
 
 	// The member's guild avatar hash
 	Avatar string `json:"avatar"`
 
 	// When the user joined the guild
-	JoinedAt time.Time `json:"joined_at"`
-
-	// When the user starting boosting the guild
-	PremiumSince time.Time `json:"premium_since,omitempty"`
-
-	// Whether the user is deafened in voice channels
-	Deaf bool `json:"deaf,omitempty"`
-
-	// Whether the user is muted in voice channels
-	Mute bool `json:"mute,omitempty"`
-
-	// Whether the user has not yet passed the guild's Membership Screening requirements
-	Pending bool `json:"pending,omitempty"`
-
-	CommunicationDisabledUntil time.Time `json:"communication_disabled_until,omitempty"`
+	JoinedAt Timestamp `json:"joined_at"`
+
+	// When the user starting boosting the guild, nil if it wasn't sent and zero if the user stopped boosting
+	PremiumSince *Timestamp `json:"premium_since,omitempty"`
+
+	// Whether the user is deafened in voice channels, nil if it wasn't sent
+	Deaf *bool `json:"deaf,omitempty"`
+
+	// Whether the user is muted in voice channels, nil if it wasn't sent
+	Mute *bool `json:"mute,omitempty"`
+
+	// Whether the user has not yet passed the guild's Membership Screening requirements, nil if it wasn't sent
+	Pending *bool `json:"pending,omitempty"`
+
+	// When the user's timeout will expire, nil if it wasn't sent and zero if the timeout was removed
+	CommunicationDisabledUntil *Timestamp `json:"communication_disabled_until,omitempty"`
+}
+
+func (g *GuildMemberUpdateEvent) UnmarshalJSON(data []byte) error {
+	type event GuildMemberUpdateEvent
+	var raw struct {
+		*event
+		Nick                       json.RawMessage `json:"nick"`
+		PremiumSince               json.RawMessage `json:"premium_since"`
+		CommunicationDisabledUntil json.RawMessage `json:"communication_disabled_until"`
+	}
+	raw.event = (*event)(g)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	// a null value removes the field, which is different to it not being sent
+	g.Nick = nil
+	if raw.Nick != nil {
+		var nick *string
+		if err := json.Unmarshal(raw.Nick, &nick); err != nil {
+			return err
+		}
+		if nick == nil {
+			nick = new(string)
+		}
+		g.Nick = nick
+	}
+
+	var err error
+	if g.PremiumSince, err = sentTimestamp(raw.PremiumSince); err != nil {
+		return err
+	}
+	if g.CommunicationDisabledUntil, err = sentTimestamp(raw.CommunicationDisabledUntil); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Decodes a timestamp that's nil if it wasn't sent and zero if it was null
+func sentTimestamp(data json.RawMessage) (*Timestamp, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var t Timestamp
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
 }
 
 func (g *GuildMemberUpdateEvent) EventType() string { return "GUILD_MEMBER_UPDATE" }
@@ -471,7 +520,7 @@ type InviteCreateEvent struct {
 	Code string `json:"code"`
 
 	// The time at which the invite was created
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt Timestamp `json:"created_at"`
 
 	// The guild of the invite
 	GuildID string `json:"guild_id,omitempty"`
@@ -665,7 +714,7 @@ type TypingStartEvent struct {
 	UserID string `json:"user_id"`
 
 	// Unix time (in seconds) of when the user started typing
-	Timestamp int `json:"timestamp"`
+	Timestamp Timestamp `json:"timestamp"`
 
 	// The member who started typing if this happened in a guild
 	Member *GuildMember `json:"member,omitempty"`
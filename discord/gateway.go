@@ -1,6 +1,9 @@
 package discord
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // https://discord.com/developers/docs/topics/gateway#payloads-gateway-payload-structure
 type GatewayPayload[T any] struct {
@@ -91,13 +94,26 @@ type GuildRequestMembers struct {
 	Nonce string `json:"nonce,omitempty"`
 }
 
+// Always sends the query when no user IDs are given, as Discord requires one of them and an empty query requests all
+// members
+func (r GuildRequestMembers) MarshalJSON() ([]byte, error) {
+	type request GuildRequestMembers
+	if len(r.UserIDs) > 0 {
+		return json.Marshal(request(r))
+	}
+	return json.Marshal(struct {
+		request
+		Query string `json:"query"`
+	}{request(r), r.Query})
+}
+
 // https://discord.com/developers/docs/topics/gateway#update-voice-state-gateway-voice-state-update-structure
 type GatewayVoiceStateUpdate struct {
 	// ID of the guild
 	GuildID string `json:"guild_id"`
 
 	// ID of the voice channel client wants to join (null if disconnecting)
-	ChannelID string `json:"channel_id"`
+	ChannelID *string `json:"channel_id"`
 
 	// Is the client muted
 	SelfMute bool `json:"self_mute"`
@@ -115,12 +131,23 @@ type GatewayPresenceUpdate struct {
 	Activities []*Activity `json:"activities"`
 
 	// The user's new status
-	Status string `json:"status"`
+	Status Status `json:"status"`
 
 	// Whether or not the client is AFK
 	AFK bool `json:"afk"`
 }
 
+// https://discord.com/developers/docs/topics/gateway#update-presence-status-types
+type Status string
+
+const (
+	StatusOnline    Status = "online"
+	StatusDND       Status = "dnd"
+	StatusIdle      Status = "idle"
+	StatusInvisible Status = "invisible"
+	StatusOffline   Status = "offline"
+)
+
 // https://discord.com/developers/docs/topics/gateway#hello-hello-structure
 type Hello struct {
 	// The interval (in milliseconds) the client should heartbeat with
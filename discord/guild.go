@@ -1,9 +1,5 @@
 package discord
 
-import (
-	"time"
-)
-
 // https://discord.com/developers/docs/resources/guild#guild-object-guild-structure
 type Guild struct {
 	// Guild ID
@@ -312,10 +308,10 @@ type GuildMember struct {
 	Roles []string `json:"roles"`
 
 	// When the user joined the guild
-	JoinedAt time.Time `json:"joined_at"`
+	JoinedAt Timestamp `json:"joined_at"`
 
 	// When the user started boosting the guild
-	PremiumSince time.Time `json:"premium_since,omitempty"`
+	PremiumSince Timestamp `json:"premium_since,omitempty"`
 
 	// Whether the user is deafened in voice channels
 	Deaf bool `json:"deaf"`
@@ -330,7 +326,7 @@ type GuildMember struct {
 	Permissions string `json:"permissions,omitempty"`
 
 	// When the user's timeout will expire and the user will be able to communicate in the guild again, null or a time in the past if the user is not timed out
-	CommunicationDisabledUntil time.Time `json:"communication_disabled_until,omitempty"`
+	CommunicationDisabledUntil Timestamp `json:"communication_disabled_until,omitempty"`
 }
 
 // https://discord.com/developers/docs/resources/guild#integration-object-integration-structure
@@ -369,7 +365,7 @@ type Integration struct {
 	Account *IntegrationAccount `json:"account"`
 
 	// When this integration was last synced
-	SyncedAt time.Time `json:"synced_at"`
+	SyncedAt Timestamp `json:"synced_at"`
 
 	// How many subscribers this integration has
 	SubscriberCount int `json:"subscriber_count"`
@@ -448,3 +444,378 @@ type WelcomeScreenChannel struct {
 	// The emoji name if custom, the unicode character if standard, or null if no emoji is set
 	EmojiName string `json:"emoji_name"`
 }
+
+// https://discord.com/developers/docs/resources/guild#create-guild
+type CreateGuild struct {
+	// Name of the guild (2-100 characters)
+	Name string `json:"name"`
+
+	// Base64 128x128 image for the guild icon
+	Icon string `json:"icon,omitempty"`
+
+	// Verification level
+	VerificationLevel VerificationLevel `json:"verification_level,omitempty"`
+
+	// Default message notification level
+	DefaultMessageNotifications DefaultMessageNotifications `json:"default_message_notifications,omitempty"`
+
+	// Explicit content filter level
+	ExplicitContentFilter ExplicitContentFilter `json:"explicit_content_filter,omitempty"`
+
+	// New guild roles
+	Roles []*Role `json:"roles,omitempty"`
+
+	// New guild's channels
+	Channels []*Channel `json:"channels,omitempty"`
+
+	// ID for afk channel
+	AFKChannelID string `json:"afk_channel_id,omitempty"`
+
+	// AFK timeout in seconds
+	AFKTimeout int `json:"afk_timeout,omitempty"`
+
+	// The ID of the channel where guild notices such as welcome messages and boost events are posted
+	SystemChannelID string `json:"system_channel_id,omitempty"`
+
+	// System channel flags
+	SystemChannelFlags SystemChannelFlags `json:"system_channel_flags,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild
+type ModifyGuild struct {
+	// Guild name
+	Name string `json:"name,omitempty"`
+
+	// Verification level
+	VerificationLevel VerificationLevel `json:"verification_level,omitempty"`
+
+	// Default message notification level
+	DefaultMessageNotifications DefaultMessageNotifications `json:"default_message_notifications,omitempty"`
+
+	// Explicit content filter level
+	ExplicitContentFilter ExplicitContentFilter `json:"explicit_content_filter,omitempty"`
+
+	// ID for afk channel
+	AFKChannelID string `json:"afk_channel_id,omitempty"`
+
+	// AFK timeout in seconds
+	AFKTimeout int `json:"afk_timeout,omitempty"`
+
+	// Base64 1024x1024 png/jpeg/gif image for the guild icon (can be animated gif when the server has the ANIMATED_ICON feature)
+	Icon string `json:"icon,omitempty"`
+
+	// User ID to transfer guild ownership to (must be owner)
+	OwnerID string `json:"owner_id,omitempty"`
+
+	// Base64 16:9 png/jpeg image for the guild splash (when the server has the INVITE_SPLASH feature)
+	Splash string `json:"splash,omitempty"`
+
+	// Base64 16:9 png/jpeg image for the guild discovery splash (when the server has the DISCOVERABLE feature)
+	DiscoverySplash string `json:"discovery_splash,omitempty"`
+
+	// Base64 16:9 png/jpeg image for the guild banner (when the server has the BANNER feature)
+	Banner string `json:"banner,omitempty"`
+
+	// The ID of the channel where guild notices such as welcome messages and boost events are posted
+	SystemChannelID string `json:"system_channel_id,omitempty"`
+
+	// System channel flags
+	SystemChannelFlags SystemChannelFlags `json:"system_channel_flags,omitempty"`
+
+	// The ID of the channel where Community guilds display rules and/or guidelines
+	RulesChannelID string `json:"rules_channel_id,omitempty"`
+
+	// The ID of the channel where admins and moderators of Community guilds receive notices from Discord
+	PublicUpdatesChannelID string `json:"public_updates_channel_id,omitempty"`
+
+	// The preferred locale of a Community guild used in server discovery and notices from Discord; defaults to "en-US"
+	PreferredLocale string `json:"preferred_locale,omitempty"`
+
+	// Enabled guild features
+	Features []GuildFeature `json:"features,omitempty"`
+
+	// The description for the guild
+	Description string `json:"description,omitempty"`
+
+	// Whether the guild's boost progress bar should be enabled
+	PremiumProgressBarEnabled bool `json:"premium_progress_bar_enabled,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#create-guild-channel
+type CreateGuildChannel struct {
+	// Channel name (1-100 characters)
+	Name string `json:"name"`
+
+	// The type of channel
+	Type ChannelType `json:"type,omitempty"`
+
+	// Channel topic (0-1024 characters)
+	Topic string `json:"topic,omitempty"`
+
+	// The bitrate (in bits) of the voice or stage channel; min 8000
+	Bitrate int `json:"bitrate,omitempty"`
+
+	// The user limit of the voice channel
+	UserLimit int `json:"user_limit,omitempty"`
+
+	// Amount of seconds a user has to wait before sending another message (0-21600)
+	RateLimitPerUser int `json:"rate_limit_per_user,omitempty"`
+
+	// Sorting position of the channel
+	Position int `json:"position,omitempty"`
+
+	// The channel's permission overwrites
+	PermissionOverwrites []*Overwrite `json:"permission_overwrites,omitempty"`
+
+	// ID of the parent category for a channel
+	ParentID string `json:"parent_id,omitempty"`
+
+	// Whether the channel is NSFW
+	NSFW bool `json:"nsfw,omitempty"`
+
+	// Channel voice region ID of the voice or stage channel, automatic when set to null
+	RTCRegion string `json:"rtc_region,omitempty"`
+
+	// The camera video quality mode of the voice channel
+	VideoQualityMode VideoQualityMode `json:"video_quality_mode,omitempty"`
+
+	// The default duration that the clients use (not the API) for newly created threads in the channel, in minutes, to automatically archive the thread after recent activity
+	DefaultAutoArchiveDuration int `json:"default_auto_archive_duration,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-channel-positions
+type ModifyGuildChannelPosition struct {
+	// Channel ID
+	ID string `json:"id"`
+
+	// Sorting position of the channel
+	Position *int `json:"position,omitempty"`
+
+	// Syncs the permission overwrites with the new parent, if moving to a new category
+	LockPermissions bool `json:"lock_permissions,omitempty"`
+
+	// The new parent ID for the channel that is moved
+	ParentID string `json:"parent_id,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#list-active-guild-threads
+type ActiveThreads struct {
+	// The active threads
+	Threads []*Channel `json:"threads"`
+
+	// A thread member object for each returned thread the current user has joined
+	Members []*ThreadMember `json:"members"`
+}
+
+// https://discord.com/developers/docs/resources/guild#list-guild-members
+type ListGuildMembers struct {
+	// Max number of members to return (1-1000)
+	Limit int `json:"limit,omitempty"`
+
+	// The highest user ID in the previous page
+	After string `json:"after,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#search-guild-members
+type SearchGuildMembers struct {
+	// Query string to match username(s) and nickname(s) against
+	Query string `json:"query"`
+
+	// Max number of members to return (1-1000)
+	Limit int `json:"limit,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#add-guild-member
+type AddGuildMember struct {
+	// An oauth2 access token granted with the guilds.join to the bot's application for the user you want to add to the guild
+	AccessToken string `json:"access_token"`
+
+	// Value to set user's nickname to
+	Nick string `json:"nick,omitempty"`
+
+	// Array of role IDs the member is assigned
+	Roles []string `json:"roles,omitempty"`
+
+	// Whether the user is muted in voice channels
+	Mute bool `json:"mute,omitempty"`
+
+	// Whether the user is deafened in voice channels
+	Deaf bool `json:"deaf,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-member
+type ModifyGuildMember struct {
+	// Value to set user's nickname to
+	Nick string `json:"nick,omitempty"`
+
+	// Array of role IDs the member is assigned
+	Roles []string `json:"roles,omitempty"`
+
+	// Whether the user is muted in voice channels
+	Mute *bool `json:"mute,omitempty"`
+
+	// Whether the user is deafened in voice channels
+	Deaf *bool `json:"deaf,omitempty"`
+
+	// ID of channel to move user to (if they are connected to voice)
+	ChannelID string `json:"channel_id,omitempty"`
+
+	// When the user's timeout will expire (up to 28 days in the future), a zero timestamp removes the timeout
+	CommunicationDisabledUntil *Timestamp `json:"communication_disabled_until,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-current-member
+type ModifyCurrentMember struct {
+	// Value to set user's nickname to
+	Nick string `json:"nick"`
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-bans
+type GetGuildBans struct {
+	// Number of users to return (up to maximum 1000)
+	Limit int `json:"limit,omitempty"`
+
+	// Consider only users before given user ID
+	Before string `json:"before,omitempty"`
+
+	// Consider only users after given user ID
+	After string `json:"after,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#create-guild-ban
+type CreateGuildBan struct {
+	// Number of days to delete messages for (0-7)
+	DeleteMessageDays int `json:"delete_message_days,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#create-guild-role
+type CreateGuildRole struct {
+	// Name of the role
+	Name string `json:"name,omitempty"`
+
+	// Bitwise value of the enabled/disabled permissions
+	Permissions string `json:"permissions,omitempty"`
+
+	// RGB color value
+	Color int `json:"color,omitempty"`
+
+	// Whether the role should be displayed separately in the sidebar
+	Hoist bool `json:"hoist,omitempty"`
+
+	// The role's icon image (if the guild has the ROLE_ICONS feature)
+	Icon string `json:"icon,omitempty"`
+
+	// The role's unicode emoji as a standard emoji (if the guild has the ROLE_ICONS feature)
+	UnicodeEmoji string `json:"unicode_emoji,omitempty"`
+
+	// Whether the role should be mentionable
+	Mentionable bool `json:"mentionable,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-role-positions
+type ModifyGuildRolePosition struct {
+	// Role ID
+	ID string `json:"id"`
+
+	// Sorting position of the role
+	Position *int `json:"position,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-role
+type ModifyGuildRole struct {
+	// Name of the role
+	Name string `json:"name,omitempty"`
+
+	// Bitwise value of the enabled/disabled permissions
+	Permissions string `json:"permissions,omitempty"`
+
+	// RGB color value
+	Color *int `json:"color,omitempty"`
+
+	// Whether the role should be displayed separately in the sidebar
+	Hoist *bool `json:"hoist,omitempty"`
+
+	// The role's icon image (if the guild has the ROLE_ICONS feature)
+	Icon string `json:"icon,omitempty"`
+
+	// The role's unicode emoji as a standard emoji (if the guild has the ROLE_ICONS feature)
+	UnicodeEmoji string `json:"unicode_emoji,omitempty"`
+
+	// Whether the role should be mentionable
+	Mentionable *bool `json:"mentionable,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-mfa-level
+type ModifyGuildMFALevel struct {
+	// MFA level
+	Level MFALevel `json:"level"`
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-prune-count
+type GetGuildPruneCount struct {
+	// Number of days to count prune for (1-30)
+	Days int `json:"days,omitempty"`
+
+	// Role(s) to include
+	IncludeRoles []string `json:"include_roles,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#begin-guild-prune
+type BeginGuildPrune struct {
+	// Number of days to prune (1-30)
+	Days int `json:"days,omitempty"`
+
+	// Whether pruned is returned, discouraged for large guilds
+	ComputePruneCount bool `json:"compute_prune_count"`
+
+	// Role(s) to include
+	IncludeRoles []string `json:"include_roles,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-prune-count
+type GuildPrune struct {
+	// The number of members that would be or were removed, null when compute_prune_count is false
+	Pruned int `json:"pruned"`
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-vanity-url
+type GuildVanityURL struct {
+	// The vanity invite code, or null if not set
+	Code string `json:"code"`
+
+	// The number of times the vanity invite has been used
+	Uses int `json:"uses"`
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-welcome-screen
+type ModifyGuildWelcomeScreen struct {
+	// Whether the welcome screen is enabled
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Channels linked in the welcome screen and their display options
+	WelcomeChannels []*WelcomeScreenChannel `json:"welcome_channels,omitempty"`
+
+	// The server description to show in the welcome screen
+	Description string `json:"description,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-current-user-voice-state
+type ModifyCurrentUserVoiceState struct {
+	// The ID of the channel the user is currently in
+	ChannelID string `json:"channel_id"`
+
+	// Toggles the user's suppress state
+	Suppress *bool `json:"suppress,omitempty"`
+
+	// Sets the user's request to speak
+	RequestToSpeakTimestamp *Timestamp `json:"request_to_speak_timestamp,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-user-voice-state
+type ModifyUserVoiceState struct {
+	// The ID of the channel the user is currently in
+	ChannelID string `json:"channel_id"`
+
+	// Toggles the user's suppress state
+	Suppress *bool `json:"suppress,omitempty"`
+}
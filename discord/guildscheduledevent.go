@@ -1,9 +1,5 @@
 package discord
 
-import (
-	"time"
-)
-
 // https://discord.com/developers/docs/resources/guild-scheduled-event#guild-scheduled-event-object-guild-scheduled-event-structure
 type GuildScheduledEvent struct {
 	// The ID of the scheduled event
@@ -25,10 +21,10 @@ type GuildScheduledEvent struct {
 	Description string `json:"description,omitempty"`
 
 	// The time the scheduled event will start
-	ScheduledStartTime time.Time `json:"scheduled_start_time"`
+	ScheduledStartTime Timestamp `json:"scheduled_start_time"`
 
 	// The time the scheduled event will end, required if entity_type is EXTERNAL
-	ScheduledEndTime time.Time `json:"scheduled_end_time"`
+	ScheduledEndTime Timestamp `json:"scheduled_end_time"`
 
 	// The privacy level of the scheduled event
 	PrivacyLevel *GuildScheduledEventPrivacyLevel `json:"privacy_level"`
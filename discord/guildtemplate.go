@@ -1,9 +1,5 @@
 package discord
 
-import (
-	"time"
-)
-
 // https://discord.com/developers/docs/resources/guild-template#guild-template-object-guild-template-structure
 type GuildTemplate struct {
 	// The template code (unique ID)
@@ -25,10 +21,10 @@ type GuildTemplate struct {
 	Creator *User `json:"creator"`
 
 	// When this template was created
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt Timestamp `json:"created_at"`
 
 	// When this template was last synced to the source guild
-	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
 
 	// The ID of the guild this template is based on
 	SourceGuildID string `json:"source_guild_id"`
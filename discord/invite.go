@@ -1,9 +1,5 @@
 package discord
 
-import (
-	"time"
-)
-
 // https://discord.com/developers/docs/resources/invite#invite-object-invite-structure
 type Invite struct {
 	// The invite code (unique ID)
@@ -34,7 +30,7 @@ type Invite struct {
 	ApproximateMemberCount int `json:"approximate_member_count,omitempty"`
 
 	// The expiration date of this invite, returned from the GET /invites/<code> endpoint when with_expiration is true
-	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	ExpiresAt Timestamp `json:"expires_at,omitempty"`
 
 	// Stage instance data if there is a public Stage instance in the Stage channel this invite is for (deprecated)
 	StageInstance *InviteStageInstance `json:"stage_instance,omitempty"`
@@ -66,7 +62,7 @@ type InviteMetadataStructure struct {
 	Temporary bool `json:"temporary"`
 
 	// When this invite was created
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt Timestamp `json:"created_at"`
 }
 
 // https://discord.com/developers/docs/resources/invite#invite-stage-instance-object-invite-stage-instance-structure
@@ -1,22 +1,123 @@
 package discord
 
+import "encoding/json"
+
 // https://discord.com/developers/docs/interactions/message-components#component-object-component-types
 type ComponentType int
 
 const (
-	ComponentTypeActionRow = iota + 1
+	ComponentTypeActionRow ComponentType = iota + 1
 	ComponentTypeButton
 	ComponentTypeSelectMenu
 	ComponentTypeTextInput
 )
 
+// A message component, one of *ActionRow, *Button, *SelectMenu or *TextInput
+type Component interface {
+	ComponentType() ComponentType
+}
+
+// A list of components, decoded into the concrete component type given by each component's type field
+type Components []Component
+
+func (c *Components) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	components := make(Components, 0, len(raw))
+	for _, r := range raw {
+		var header struct {
+			Type ComponentType `json:"type"`
+		}
+		if err := json.Unmarshal(r, &header); err != nil {
+			return err
+		}
+
+		var component Component
+		switch header.Type {
+		case ComponentTypeActionRow:
+			component = &ActionRow{}
+		case ComponentTypeButton:
+			component = &Button{}
+		case ComponentTypeSelectMenu:
+			component = &SelectMenu{}
+		case ComponentTypeTextInput:
+			component = &TextInput{}
+		default:
+			// keep unknown components so they aren't lost when a message is edited
+			component = &UnknownComponent{}
+		}
+		if err := json.Unmarshal(r, component); err != nil {
+			return err
+		}
+		components = append(components, component)
+	}
+	*c = components
+
+	return nil
+}
+
+// https://discord.com/developers/docs/interactions/message-components#action-rows
+type ActionRow struct {
+	// 1 for an action row
+	Type ComponentType `json:"type"`
+
+	// Up to 5 buttons, a single select menu, or a single text input in a modal
+	Components Components `json:"components"`
+}
+
+func (r *ActionRow) ComponentType() ComponentType {
+	return ComponentTypeActionRow
+}
+
+func (r ActionRow) MarshalJSON() ([]byte, error) {
+	type actionRow ActionRow
+	r.Type = ComponentTypeActionRow
+	return json.Marshal(actionRow(r))
+}
+
+// A component of a type that isn't supported, kept as its raw JSON
+type UnknownComponent struct {
+	// The component's type
+	Type ComponentType
+
+	// The raw JSON of the component
+	Raw json.RawMessage
+}
+
+func (u *UnknownComponent) ComponentType() ComponentType {
+	return u.Type
+}
+
+func (u *UnknownComponent) UnmarshalJSON(data []byte) error {
+	var header struct {
+		Type ComponentType `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+	u.Type = header.Type
+	u.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+func (u UnknownComponent) MarshalJSON() ([]byte, error) {
+	// a component that wasn't decoded has no JSON to send back
+	if len(u.Raw) == 0 {
+		return []byte("null"), nil
+	}
+	return u.Raw, nil
+}
+
 // https://discord.com/developers/docs/interactions/message-components#button-object-button-structure
 type Button struct {
 	// 2 for a button
 	Type ComponentType `json:"type"`
 
 	// One of button styles
-	Style int `json:"style"`
+	Style ButtonStyle `json:"style"`
 
 	// Text that appears on the button, max 80 characters
 	Label string `json:"label,omitempty"`
@@ -34,6 +135,27 @@ type Button struct {
 	Disabled bool `json:"disabled,omitempty"`
 }
 
+func (b *Button) ComponentType() ComponentType {
+	return ComponentTypeButton
+}
+
+func (b Button) MarshalJSON() ([]byte, error) {
+	type button Button
+	b.Type = ComponentTypeButton
+	return json.Marshal(button(b))
+}
+
+// https://discord.com/developers/docs/interactions/message-components#button-object-button-styles
+type ButtonStyle int
+
+const (
+	ButtonStylePrimary ButtonStyle = iota + 1
+	ButtonStyleSecondary
+	ButtonStyleSuccess
+	ButtonStyleDanger
+	ButtonStyleLink
+)
+
 // https://discord.com/developers/docs/interactions/message-components#select-menu-object-select-menu-structure
 type SelectMenu struct {
 	// 3 for a select menu
@@ -58,6 +180,16 @@ type SelectMenu struct {
 	Disabled bool `json:"disabled,omitempty"`
 }
 
+func (s *SelectMenu) ComponentType() ComponentType {
+	return ComponentTypeSelectMenu
+}
+
+func (s SelectMenu) MarshalJSON() ([]byte, error) {
+	type selectMenu SelectMenu
+	s.Type = ComponentTypeSelectMenu
+	return json.Marshal(selectMenu(s))
+}
+
 // https://discord.com/developers/docs/interactions/message-components#select-menu-object-select-option-structure
 type SelectOption struct {
 	// The user-facing name of the option, max 100 characters
@@ -85,7 +217,7 @@ type TextInput struct {
 	CustomID string `json:"custom_id"`
 
 	// The Text Input Style
-	Style int `json:"style"`
+	Style TextInputStyle `json:"style"`
 
 	// The label for this component, max 45 characters
 	Label string `json:"label"`
@@ -97,7 +229,7 @@ type TextInput struct {
 	MaxLength int `json:"max_length,omitempty"`
 
 	// Whether this component is required to be filled, default true
-	Required bool `json:"required,omitempty"`
+	Required *bool `json:"required,omitempty"`
 
 	// A pre-filled value for this component, max 4000 characters
 	Value string `json:"value,omitempty"`
@@ -105,3 +237,24 @@ type TextInput struct {
 	// Custom placeholder text if the input is empty, max 100 characters
 	Placeholder string `json:"placeholder,omitempty"`
 }
+
+func (t *TextInput) ComponentType() ComponentType {
+	return ComponentTypeTextInput
+}
+
+func (t TextInput) MarshalJSON() ([]byte, error) {
+	type textInput TextInput
+	t.Type = ComponentTypeTextInput
+	return json.Marshal(textInput(t))
+}
+
+// https://discord.com/developers/docs/interactions/message-components#text-inputs-text-input-styles
+type TextInputStyle int
+
+const (
+	// A single-line input
+	TextInputStyleShort TextInputStyle = iota + 1
+
+	// A multi-line input
+	TextInputStyleParagraph
+)
@@ -0,0 +1,22 @@
+package discord
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnknownComponentMarshalJSON(t *testing.T) {
+	var u UnknownComponent
+	if err := json.Unmarshal([]byte(`{"type":99,"custom_id":"new"}`), &u); err != nil {
+		t.Fatalf("error decoding component: %s", err)
+	}
+	data, err := json.Marshal(u)
+	if err != nil || string(data) != `{"type":99,"custom_id":"new"}` {
+		t.Fatalf("expected the raw JSON, got %s and %v", data, err)
+	}
+
+	data, err = json.Marshal(UnknownComponent{Type: 99})
+	if err != nil || string(data) != "null" {
+		t.Fatalf("expected null for a component without raw JSON, got %s and %v", data, err)
+	}
+}
@@ -1,5 +1,7 @@
 package discord
 
+import "encoding/json"
+
 // https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object-interaction-structure
 type Interaction struct {
 	// ID of the interaction
@@ -80,13 +82,13 @@ type InteractionData struct {
 	ComponentType ComponentType `json:"component_type,omitempty"`
 
 	// The values the user selected
-	Values []*SelectOption `json:"values,omitempty"`
+	Values []string `json:"values,omitempty"`
 
 	// ID of the user or message targeted by a user or message command
 	TargetID string `json:"target_id,omitempty"`
 
-	// The values submitted by the user
-	// Components []*MessageComponent `json:"components,omitempty"`
+	// The values submitted by the user in a modal
+	Components Components `json:"components,omitempty"`
 }
 
 // https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object-resolved-data-structure
@@ -167,11 +169,32 @@ type InteractionCallbackData struct {
 	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
 
 	// Message flags combined as a bitfield (only SUPPRESS_EMBEDS and EPHEMERAL can be set)
-	Flags int `json:"flags,omitempty"`
+	Flags MessageFlags `json:"flags,omitempty"`
 
-	// Message components
-	// Components []*Component `json:"components,omitempty"`
+	// Message components, or between 1 and 5 action rows of text inputs for a modal
+	Components Components `json:"components,omitempty"`
 
 	// Attachment objects with filename and description
-	Attachments []*Attachment `json:"attachments"`
+	Attachments []*Attachment `json:"attachments,omitempty"`
+
+	// Autocomplete choices, max 25
+	Choices []*ApplicationCommandOptionChoice `json:"choices,omitempty"`
+
+	// A developer-defined identifier for a modal, max 100 characters
+	CustomID string `json:"custom_id,omitempty"`
+
+	// The title of a modal, max 45 characters
+	Title string `json:"title,omitempty"`
+}
+
+// Always sends the choices when they're set, as autocomplete results with no matches need an empty array
+func (d InteractionCallbackData) MarshalJSON() ([]byte, error) {
+	type data InteractionCallbackData
+	if d.Choices == nil {
+		return json.Marshal(data(d))
+	}
+	return json.Marshal(struct {
+		data
+		Choices []*ApplicationCommandOptionChoice `json:"choices"`
+	}{data(d), d.Choices})
 }
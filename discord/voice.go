@@ -1,9 +1,5 @@
 package discord
 
-import (
-	"time"
-)
-
 // https://discord.com/developers/docs/resources/voice#voice-state-object-voice-state-structure
 type VoiceState struct {
 	// The guild ID this voice state is for
@@ -43,7 +39,7 @@ type VoiceState struct {
 	Suppress bool `json:"suppress"`
 
 	// The time at which the user requested to speak
-	RequestToSpeakTimestamp time.Time `json:"request_to_speak_timestamp"`
+	RequestToSpeakTimestamp Timestamp `json:"request_to_speak_timestamp"`
 }
 
 // https://discord.com/developers/docs/resources/voice#voice-region-object-voice-region-structure
@@ -0,0 +1,114 @@
+package discord
+
+// https://discord.com/developers/docs/topics/voice-connections#establishing-a-voice-websocket-connection-example-voice-identify-payload
+type VoiceIdentify struct {
+	// ID of the guild being connected to
+	ServerID string `json:"server_id"`
+
+	// ID of the connecting user
+	UserID string `json:"user_id"`
+
+	// Session ID from the voice state update
+	SessionID string `json:"session_id"`
+
+	// Token from the voice server update
+	Token string `json:"token"`
+}
+
+// https://discord.com/developers/docs/topics/voice-connections#establishing-a-voice-websocket-connection-example-voice-ready-payload
+type VoiceReady struct {
+	// SSRC the connection's audio is sent with
+	SSRC uint32 `json:"ssrc"`
+
+	// IP of the voice UDP server
+	IP string `json:"ip"`
+
+	// Port of the voice UDP server
+	Port int `json:"port"`
+
+	// Supported encryption modes
+	Modes []string `json:"modes"`
+}
+
+// https://discord.com/developers/docs/topics/voice-connections#heartbeating-example-hello-payload
+type VoiceHello struct {
+	// Interval (in milliseconds) heartbeats should be sent at
+	HeartbeatInterval float64 `json:"heartbeat_interval"`
+}
+
+// https://discord.com/developers/docs/topics/voice-connections#establishing-a-voice-udp-connection-example-select-protocol-payload
+type VoiceSelectProtocol struct {
+	// Voice protocol, always "udp"
+	Protocol string `json:"protocol"`
+
+	// The client's external address and the chosen encryption mode
+	Data *VoiceSelectProtocolData `json:"data"`
+}
+
+// https://discord.com/developers/docs/topics/voice-connections#establishing-a-voice-udp-connection-example-select-protocol-payload
+type VoiceSelectProtocolData struct {
+	// External IP discovered for the client
+	Address string `json:"address"`
+
+	// External port discovered for the client
+	Port int `json:"port"`
+
+	// Encryption mode chosen from the modes in the ready payload
+	Mode string `json:"mode"`
+}
+
+// https://discord.com/developers/docs/topics/voice-connections#establishing-a-voice-udp-connection-example-session-description-payload
+type VoiceSessionDescription struct {
+	// Encryption mode in use
+	Mode string `json:"mode"`
+
+	// Key used to encrypt and decrypt voice packets
+	SecretKey [32]byte `json:"secret_key"`
+}
+
+// https://discord.com/developers/docs/topics/voice-connections#speaking
+type VoiceSpeaking struct {
+	// Speaking mode flags
+	Speaking SpeakingFlags `json:"speaking"`
+
+	// Should be 0 for bots
+	Delay int `json:"delay"`
+
+	// SSRC of the speaking user's audio
+	SSRC uint32 `json:"ssrc"`
+
+	// ID of the speaking user, only sent by the server
+	UserID string `json:"user_id,omitempty"`
+}
+
+// https://discord.com/developers/docs/topics/voice-connections#speaking
+type SpeakingFlags int
+
+const (
+	// Normal transmission of voice audio
+	SpeakingFlagsMicrophone SpeakingFlags = 1 << iota
+
+	// Transmission of context audio for video, no speaking indicator
+	SpeakingFlagsSoundshare
+
+	// Priority speaker, lowering audio of other speakers
+	SpeakingFlagsPriority
+)
+
+// https://discord.com/developers/docs/topics/voice-connections#resuming-voice-connection-example-resume-connection-payload
+type VoiceResume struct {
+	// ID of the guild being connected to
+	ServerID string `json:"server_id"`
+
+	// Session ID from the voice state update
+	SessionID string `json:"session_id"`
+
+	// Token from the voice server update
+	Token string `json:"token"`
+}
+
+// Sent when a user disconnects from the voice channel
+type VoiceClientDisconnect struct {
+	// ID of the user that disconnected
+	UserID string `json:"user_id"`
+}
@@ -6,7 +6,7 @@ type Webhook struct {
 	ID string `json:"id"`
 
 	// The type of the webhook
-	Type int `json:"type"`
+	Type WebhookType `json:"type"`
 
 	// The guild ID this webhook is for, if any
 	GuildID string `json:"guild_id,omitempty"`
@@ -38,3 +38,93 @@ type Webhook struct {
 	// The URL used for executing the webhook (returned by the webhooks OAuth2 flow)
 	URL string `json:"url,omitempty"`
 }
+
+// https://discord.com/developers/docs/resources/webhook#webhook-object-webhook-types
+type WebhookType int
+
+const (
+	WebhookTypeIncoming WebhookType = iota + 1
+	WebhookTypeChannelFollower
+	WebhookTypeApplication
+)
+
+// https://discord.com/developers/docs/resources/webhook#create-webhook
+type CreateWebhook struct {
+	// Name of the webhook (1-80 characters)
+	Name string `json:"name"`
+
+	// Image for the default webhook avatar
+	Avatar string `json:"avatar,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/webhook#modify-webhook
+type ModifyWebhook struct {
+	// The default name of the webhook
+	Name string `json:"name,omitempty"`
+
+	// Image for the default webhook avatar
+	Avatar string `json:"avatar,omitempty"`
+
+	// The new channel ID this webhook should be moved to, not allowed when modifying with a token
+	ChannelID string `json:"channel_id,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/webhook#execute-webhook
+type ExecuteWebhook struct {
+	// The message contents (up to 2000 characters)
+	Content string `json:"content,omitempty"`
+
+	// Override the default username of the webhook
+	Username string `json:"username,omitempty"`
+
+	// Override the default avatar of the webhook
+	AvatarURL string `json:"avatar_url,omitempty"`
+
+	// True if this is a TTS message
+	TTS bool `json:"tts,omitempty"`
+
+	// Embedded rich content (up to 10 embeds)
+	Embeds []*Embed `json:"embeds,omitempty"`
+
+	// Allowed mentions for the message
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+
+	// Attachment objects with filename and description
+	Attachments []*Attachment `json:"attachments,omitempty"`
+
+	// Components to include with the message, requires an application-owned webhook
+	Components Components `json:"components,omitempty"`
+
+	// Message flags combined as a bitfield (only SUPPRESS_EMBEDS can be set)
+	Flags MessageFlags `json:"flags,omitempty"`
+
+	// Name of thread to create (requires the webhook channel to be a forum channel)
+	ThreadName string `json:"thread_name,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/webhook#execute-webhook-query-string-params
+type ExecuteWebhookQuery struct {
+	// Waits for server confirmation of message send before response, and returns the created message body
+	Wait bool `json:"wait,omitempty"`
+
+	// Send a message to the specified thread within a webhook's channel
+	ThreadID string `json:"thread_id,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/webhook#edit-webhook-message
+type EditWebhookMessage struct {
+	// The message contents (up to 2000 characters)
+	Content string `json:"content,omitempty"`
+
+	// Embedded rich content (up to 10 embeds)
+	Embeds []*Embed `json:"embeds,omitempty"`
+
+	// Allowed mentions for the message
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+
+	// Attached files to keep and possible descriptions for new files
+	Attachments []*Attachment `json:"attachments,omitempty"`
+
+	// Components to include with the message, requires an application-owned webhook
+	Components Components `json:"components,omitempty"`
+}
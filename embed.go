@@ -0,0 +1,221 @@
+package eventide
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Builds an embed, validating it against Discord's limits when built
+type EmbedBuilder struct {
+	embed *discord.Embed
+}
+
+// Creates an embed builder
+func NewEmbed() *EmbedBuilder {
+	return &EmbedBuilder{
+		embed: &discord.Embed{},
+	}
+}
+
+// Sets the title of the embed
+func (b *EmbedBuilder) Title(title string) *EmbedBuilder {
+	b.embed.Title = title
+	return b
+}
+
+// Sets the description of the embed
+func (b *EmbedBuilder) Description(description string) *EmbedBuilder {
+	b.embed.Description = description
+	return b
+}
+
+// Sets the URL the embed's title links to
+func (b *EmbedBuilder) URL(url string) *EmbedBuilder {
+	b.embed.URL = url
+	return b
+}
+
+// Sets the color of the embed's border as an RGB integer, such as 0x5865F2
+func (b *EmbedBuilder) Color(color int) *EmbedBuilder {
+	b.embed.Color = color
+	return b
+}
+
+// Sets the timestamp shown in the embed's footer
+func (b *EmbedBuilder) Timestamp(t time.Time) *EmbedBuilder {
+	b.embed.Timestamp = &discord.Timestamp{Time: t}
+	return b
+}
+
+// Adds a field to the embed
+func (b *EmbedBuilder) Field(name string, value string, inline bool) *EmbedBuilder {
+	b.embed.Fields = append(b.embed.Fields, &discord.EmbedField{
+		Name:   name,
+		Value:  value,
+		Inline: inline,
+	})
+	return b
+}
+
+// Sets the author of the embed, url and iconURL can be empty
+func (b *EmbedBuilder) Author(name string, url string, iconURL string) *EmbedBuilder {
+	b.embed.Author = &discord.EmbedAuthor{
+		Name:    name,
+		URL:     url,
+		IconURL: iconURL,
+	}
+	return b
+}
+
+// Sets the footer of the embed, iconURL can be empty
+func (b *EmbedBuilder) Footer(text string, iconURL string) *EmbedBuilder {
+	b.embed.Footer = &discord.EmbedFooter{
+		Text:    text,
+		IconURL: iconURL,
+	}
+	return b
+}
+
+// Sets the image of the embed
+func (b *EmbedBuilder) Image(url string) *EmbedBuilder {
+	b.embed.Image = &discord.EmbedImage{URL: url}
+	return b
+}
+
+// Sets the thumbnail of the embed
+func (b *EmbedBuilder) Thumbnail(url string) *EmbedBuilder {
+	b.embed.Thumbnail = &discord.EmbedThumbnail{URL: url}
+	return b
+}
+
+// Validates the embed against Discord's limits and returns it
+func (b *EmbedBuilder) Build() (*discord.Embed, error) {
+	if err := b.embed.Validate(); err != nil {
+		return nil, err
+	}
+	return b.embed, nil
+}
+
+// Splits content into chunks of at most limit characters, preferring to split at line breaks and then spaces. Limits
+// below 1 default to discord.MessageContentLimit
+func SplitContent(content string, limit int) []string {
+	if limit <= 0 {
+		limit = discord.MessageContentLimit
+	}
+
+	var chunks []string
+
+	for utf8.RuneCountInString(content) > limit {
+		// byte offset of the character at the limit
+		end := 0
+		for n := 0; n < limit; n++ {
+			_, size := utf8.DecodeRuneInString(content[end:])
+			end += size
+		}
+
+		split := strings.LastIndex(content[:end], "\n")
+		if split <= 0 {
+			split = strings.LastIndex(content[:end], " ")
+		}
+		if split <= 0 {
+			chunks = append(chunks, content[:end])
+			content = content[end:]
+			continue
+		}
+
+		chunks = append(chunks, content[:split])
+		content = content[split+1:]
+	}
+
+	if content != "" {
+		chunks = append(chunks, content)
+	}
+
+	return chunks
+}
+
+// Splits an embed with an oversized description or too many fields into several embeds that are within Discord's
+// limits. The title, author and thumbnail are kept on the first embed, and the footer, timestamp and image on the last.
+// Field values that are too long are continued in fields with blank names
+func SplitEmbed(e *discord.Embed) []*discord.Embed {
+	var embeds []*discord.Embed
+
+	current := &discord.Embed{
+		Title:     e.Title,
+		Type:      e.Type,
+		URL:       e.URL,
+		Color:     e.Color,
+		Author:    e.Author,
+		Thumbnail: e.Thumbnail,
+	}
+	next := func() {
+		embeds = append(embeds, current)
+		current = &discord.Embed{Color: e.Color}
+	}
+
+	// room is left for the footer, which is added to the last embed
+	limit := discord.EmbedTotalLimit
+	if e.Footer != nil {
+		limit -= utf8.RuneCountInString(e.Footer.Text)
+	}
+
+	for n, chunk := range SplitContent(e.Description, discord.EmbedDescriptionLimit) {
+		if n > 0 || current.Length()+utf8.RuneCountInString(chunk) > limit {
+			next()
+		}
+		current.Description = chunk
+	}
+
+	for _, f := range e.Fields {
+		for n, value := range SplitContent(f.Value, discord.EmbedFieldValueLimit) {
+			field := &discord.EmbedField{
+				Name:   f.Name,
+				Value:  value,
+				Inline: f.Inline,
+			}
+			if n > 0 {
+				field.Name = "\u200b"
+			}
+
+			length := utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+			if len(current.Fields) == discord.EmbedFieldsLimit || current.Length()+length > limit {
+				next()
+			}
+			current.Fields = append(current.Fields, field)
+		}
+	}
+
+	current.Footer = e.Footer
+	current.Timestamp = e.Timestamp
+	current.Image = e.Image
+	embeds = append(embeds, current)
+
+	return embeds
+}
+
+// Groups embeds into as few messages as possible without exceeding the number of embeds or total characters allowed
+// in a message
+func GroupEmbeds(embeds []*discord.Embed) [][]*discord.Embed {
+	var groups [][]*discord.Embed
+	var group []*discord.Embed
+	total := 0
+
+	for _, e := range embeds {
+		length := e.Length()
+		if len(group) == discord.MessageEmbedsLimit || (len(group) > 0 && total+length > discord.EmbedTotalLimit) {
+			groups = append(groups, group)
+			group = nil
+			total = 0
+		}
+		group = append(group, e)
+		total += length
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+
+	return groups
+}
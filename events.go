@@ -3,6 +3,7 @@ package eventide
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"github.com/thefakequake/eventide/discord"
 )
@@ -80,12 +81,12 @@ func NewEventCodec(events ...discord.Event) *EventCodec {
 
 func (c *EventCodec) DecodeEvent(op discord.GatewayPayload[json.RawMessage]) (discord.Event, error) {
 	e, ok := c.events[op.Type]
-	fmt.Printf("%s called: %v\n", op.Type, e)
-
 	if !ok {
 		return nil, fmt.Errorf("unknown event: %s", op.Type)
 	}
-	err := json.Unmarshal(op.Data, &e)
+	// decode into a new value each time so concurrently handled events don't share data
+	e = reflect.New(reflect.TypeOf(e).Elem()).Interface().(discord.Event)
+	err := json.Unmarshal(op.Data, e)
 
 	return e, err
 }
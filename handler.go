@@ -41,6 +41,15 @@ func (c *Client) runHandlers(op discord.GatewayPayload[json.RawMessage]) {
 		c.log(LogWarn, "failed to decode event: %s", err)
 		return
 	}
+	c.dispatch(e)
+
+	if i, ok := e.(*discord.InteractionCreateEvent); ok {
+		c.dispatch(c.NewInteraction(i))
+	}
+}
+
+// Calls the handlers registered for the value's type
+func (c *Client) dispatch(e any) {
 	v := reflect.ValueOf(e)
 
 	c.handlersLock.RLock()
@@ -55,6 +64,7 @@ func (c *Client) registerDefaultHandlers() {
 	c.AddHandler(func(r *discord.ReadyEvent) {
 		c.Lock()
 		c.User = r.User
+		c.Application = r.Application
 		c.sessionID = r.SessionID
 		c.Unlock()
 	})
@@ -76,4 +86,14 @@ func (c *Client) registerDefaultHandlers() {
 		delete(c.Guilds, g.ID)
 		c.guildsLock.Unlock()
 	})
+
+	c.AddHandler(c.handleVoiceStateUpdate)
+	c.AddHandler(c.handleVoiceServerUpdate)
+
+	c.AddHandler(c.handleMembersChunk)
+	c.AddHandler(c.handleMemberGuildCreate)
+	c.AddHandler(c.handleMemberGuildDelete)
+	c.AddHandler(c.handleMemberAdd)
+	c.AddHandler(c.handleMemberUpdate)
+	c.AddHandler(c.handleMemberRemove)
 }
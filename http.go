@@ -2,11 +2,15 @@ package eventide
 
 import (
 	"bytes"
+	"context"
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"reflect"
+	"strings"
 
 	"github.com/thefakequake/eventide/discord"
 )
@@ -19,15 +23,47 @@ type HTTPError struct {
 }
 
 type ErrorMessage struct {
-	Code int    `json:"code"`
+	// Discord's JSON error code
+	Code ErrorCode `json:"code"`
+
+	// Description of the error
 	Text string `json:"message"`
+
+	// Validation errors for the fields of the request body, sorted by path
+	Errors []*FieldError `json:"-"`
+
+	// Whether the body had a code, as rate limit and server error bodies don't
+	hasCode bool
 }
 
 func (e HTTPError) Error() string {
-	return fmt.Sprintf("http %d: %s", e.Response.StatusCode, e.ResponseBody)
+	if e.Message == nil {
+		return fmt.Sprintf("http %d: %s", e.Response.StatusCode, e.ResponseBody)
+	}
+
+	msg := fmt.Sprintf("http %d: %s", e.Response.StatusCode, e.Message.Text)
+	if e.Message.hasCode {
+		msg += fmt.Sprintf(" (%d)", e.Message.Code)
+	}
+	for _, f := range e.Message.Errors {
+		msg += fmt.Sprintf(", %s", f)
+	}
+	return msg
+}
+
+// Returns the error's JSON error code, so that errors.Is can be used to check for a code
+func (e HTTPError) Unwrap() error {
+	if e.Message == nil || !e.Message.hasCode {
+		return nil
+	}
+	return e.Message.Code
+}
+
+func (c *Client) Request(method string, url string, body interface{}, opts ...RequestOption) ([]byte, error) {
+	return c.RequestWithContext(context.Background(), method, url, body, opts...)
 }
 
-func (c *Client) Request(method string, url string, body interface{}) ([]byte, error) {
+func (c *Client) RequestWithContext(ctx context.Context, method string, url string, body interface{}, opts ...RequestOption) ([]byte, error) {
 	var err error
 	var reader io.Reader
 
@@ -39,15 +75,30 @@ func (c *Client) Request(method string, url string, body interface{}) ([]byte, e
 		reader = bytes.NewBuffer(dat)
 	}
 
-	req, err := http.NewRequest(method, url, reader)
+	if c.apiURL != "" && strings.HasPrefix(url, defaultEndpointAPI) {
+		url = c.apiURL + strings.TrimPrefix(url, defaultEndpointAPI)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
 	if c.token != "" {
 		req.Header.Set("Authorization", c.token)
 	}
+	if reason, ok := ctx.Value(auditLogReasonKey{}).(string); ok {
+		if err := WithReason(reason)(req); err != nil {
+			return nil, err
+		}
+	}
+	for _, opt := range opts {
+		if err := opt(req); err != nil {
+			return nil, err
+		}
+	}
 
 	resp, err := c.http.Do(req)
 	if err != nil {
@@ -80,6 +131,57 @@ func (c *Client) Request(method string, url string, body interface{}) ([]byte, e
 	return respBody, err
 }
 
+// Encodes the JSON tagged fields of params as a URL query string, omitting zero values
+func queryString(params any) string {
+	v := reflect.ValueOf(params)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	q := url.Values{}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		field := v.Field(i)
+		if name == "" || name == "-" || field.IsZero() {
+			continue
+		}
+		for field.Kind() == reflect.Pointer {
+			field = field.Elem()
+		}
+
+		if m, ok := field.Interface().(encoding.TextMarshaler); ok {
+			text, err := m.MarshalText()
+			if err == nil {
+				q.Set(name, string(text))
+			}
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.Slice:
+			values := make([]string, field.Len())
+			for j := range values {
+				values[j] = fmt.Sprint(field.Index(j).Interface())
+			}
+			q.Set(name, strings.Join(values, ","))
+		default:
+			q.Set(name, fmt.Sprint(field.Interface()))
+		}
+	}
+
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
+
 func (c *Client) GetGateway() (string, error) {
 	var err error
 
@@ -92,15 +194,15 @@ func (c *Client) GetGateway() (string, error) {
 	err = json.Unmarshal(body, &data)
 
 	v := url.Values{}
-	v.Add("v", discord.APIVersion)
+	v.Add("v", c.apiVersion)
 	v.Add("encoding", "json")
 
 	return data.URL + "?" + v.Encode(), err
 }
 
 // https://discord.com/developers/docs/resources/audit-log#get-guild-audit-log
-func (c *Client) GetGuildAuditLog(guildID string) (*discord.AuditLog, error) {
-	body, err := c.Request("GET", discord.EndpointGuildAuditLog(guildID), nil)
+func (c *Client) GetGuildAuditLog(guildID string, params *discord.GetGuildAuditLog, opts ...RequestOption) (*discord.AuditLog, error) {
+	body, err := c.Request("GET", discord.EndpointGuildAuditLog(guildID)+queryString(params), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -125,8 +227,8 @@ func (c *Client) GetChannel(channelID string) (*discord.Channel, error) {
 }
 
 // https://discord.com/developers/docs/resources/channel#modify-channel
-func (c *Client) ModifyChannel(channelID string, params *discord.ModifyChannel) (*discord.Channel, error) {
-	body, err := c.Request("PATCH", discord.EndpointChannel(channelID), params)
+func (c *Client) ModifyChannel(channelID string, params *discord.ModifyChannel, opts ...RequestOption) (*discord.Channel, error) {
+	body, err := c.Request("PATCH", discord.EndpointChannel(channelID), params, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -138,8 +240,8 @@ func (c *Client) ModifyChannel(channelID string, params *discord.ModifyChannel)
 }
 
 // https://discord.com/developers/docs/resources/channel#deleteclose-channel
-func (c *Client) DeleteChannel(channelID string) (*discord.Channel, error) {
-	body, err := c.Request("DELETE", discord.EndpointChannel(channelID), nil)
+func (c *Client) DeleteChannel(channelID string, opts ...RequestOption) (*discord.Channel, error) {
+	body, err := c.Request("DELETE", discord.EndpointChannel(channelID), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -151,8 +253,8 @@ func (c *Client) DeleteChannel(channelID string) (*discord.Channel, error) {
 }
 
 // https://discord.com/developers/docs/resources/channel#get-channel-messages
-func (c *Client) GetChannelMessages(channelID string, params *discord.GetChannelMessages) ([]*discord.Message, error) {
-	body, err := c.Request("GET", discord.EndpointChannelMessages(channelID), params)
+func (c *Client) GetChannelMessages(channelID string, params *discord.GetChannelMessages, opts ...RequestOption) ([]*discord.Message, error) {
+	body, err := c.Request("GET", discord.EndpointChannelMessages(channelID)+queryString(params), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +280,12 @@ func (c *Client) GetChannelMessage(channelID string, messageID string) (*discord
 
 // https://discord.com/developers/docs/resources/channel#create-message
 func (c *Client) CreateMessage(channelID string, params *discord.CreateMessage) (*discord.Message, error) {
+	if c.validateMessages {
+		if err := params.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	body, err := c.Request("POST", discord.EndpointChannelMessages(channelID), params)
 	if err != nil {
 		return nil, err
@@ -221,8 +329,8 @@ func (c *Client) DeleteUserReaction(channelID string, messageID string, emoji st
 }
 
 // https://discord.com/developers/docs/resources/channel#get-reactions
-func (c *Client) GetReactions(channelID string, messageID string, emoji string, params *discord.GetReactions) ([]*discord.User, error) {
-	body, err := c.Request("GET", discord.EndpointReactionsEmoji(channelID, messageID, emoji), params)
+func (c *Client) GetReactions(channelID string, messageID string, emoji string, params *discord.GetReactions, opts ...RequestOption) ([]*discord.User, error) {
+	body, err := c.Request("GET", discord.EndpointReactionsEmoji(channelID, messageID, emoji)+queryString(params), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -259,8 +367,8 @@ func (c *Client) EditMessage(channelID string, messageID string, params *discord
 }
 
 // https://discord.com/developers/docs/resources/channel#delete-message
-func (c *Client) DeleteMessage(channelID string, messageID string) (*discord.Message, error) {
-	body, err := c.Request("DELETE", discord.EndpointChannelMessage(channelID, messageID), nil)
+func (c *Client) DeleteMessage(channelID string, messageID string, opts ...RequestOption) (*discord.Message, error) {
+	body, err := c.Request("DELETE", discord.EndpointChannelMessage(channelID, messageID), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -272,14 +380,14 @@ func (c *Client) DeleteMessage(channelID string, messageID string) (*discord.Mes
 }
 
 // https://discord.com/developers/docs/resources/channel#bulk-delete-messages
-func (c *Client) BulkDeleteMessages(channelID string, params *discord.BulkDeleteMessages) error {
-	_, err := c.Request("POST", discord.EndpointBulkDeleteMessages(channelID), params)
+func (c *Client) BulkDeleteMessages(channelID string, params *discord.BulkDeleteMessages, opts ...RequestOption) error {
+	_, err := c.Request("POST", discord.EndpointBulkDeleteMessages(channelID), params, opts...)
 	return err
 }
 
 // https://discord.com/developers/docs/resources/channel#edit-channel-permissions
-func (c *Client) EditChannelPermissions(channelID string, overwriteID string, params discord.EditChannelPermissions) error {
-	_, err := c.Request("PUT", discord.EndpointChannelPermission(channelID, overwriteID), params)
+func (c *Client) EditChannelPermissions(channelID string, overwriteID string, params discord.EditChannelPermissions, opts ...RequestOption) error {
+	_, err := c.Request("PUT", discord.EndpointChannelPermission(channelID, overwriteID), params, opts...)
 	return err
 }
 
@@ -297,8 +405,8 @@ func (c *Client) GetChannelInvites(channelID string) ([]*discord.Invite, error)
 }
 
 // https://discord.com/developers/docs/resources/channel#create-channel-invite
-func (c *Client) CreateChannelInvite(channelID string, params *discord.CreateChannelInvite) (*discord.Invite, error) {
-	body, err := c.Request("POST", discord.EndpointChannelInvites(channelID), params)
+func (c *Client) CreateChannelInvite(channelID string, params *discord.CreateChannelInvite, opts ...RequestOption) (*discord.Invite, error) {
+	body, err := c.Request("POST", discord.EndpointChannelInvites(channelID), params, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -310,8 +418,8 @@ func (c *Client) CreateChannelInvite(channelID string, params *discord.CreateCha
 }
 
 // https://discord.com/developers/docs/resources/channel#delete-channel-permission
-func (c *Client) DeleteChannelPermission(channelID string, overwriteID string) error {
-	_, err := c.Request("DELETE", discord.EndpointChannelPermission(channelID, overwriteID), nil)
+func (c *Client) DeleteChannelPermission(channelID string, overwriteID string, opts ...RequestOption) error {
+	_, err := c.Request("DELETE", discord.EndpointChannelPermission(channelID, overwriteID), nil, opts...)
 	return err
 }
 
@@ -348,20 +456,20 @@ func (c *Client) GetPinnedMessages(channelID string) ([]*discord.Message, error)
 }
 
 // https://discord.com/developers/docs/resources/channel#pin-message
-func (c *Client) PinMessage(channelID string, messageID string) error {
-	_, err := c.Request("PUT", discord.EndpointPinnedMessage(channelID, messageID), nil)
+func (c *Client) PinMessage(channelID string, messageID string, opts ...RequestOption) error {
+	_, err := c.Request("PUT", discord.EndpointPinnedMessage(channelID, messageID), nil, opts...)
 	return err
 }
 
 // https://discord.com/developers/docs/resources/channel#unpin-message
-func (c *Client) UnpinMessage(channelID string, messageID string) error {
-	_, err := c.Request("DELETE", discord.EndpointPinnedMessage(channelID, messageID), nil)
+func (c *Client) UnpinMessage(channelID string, messageID string, opts ...RequestOption) error {
+	_, err := c.Request("DELETE", discord.EndpointPinnedMessage(channelID, messageID), nil, opts...)
 	return err
 }
 
 // https://discord.com/developers/docs/resources/channel#start-thread-from-message
-func (c *Client) StartThreadFromMessage(channelID string, messageID string, params *discord.StartThreadFromMessage) (*discord.Channel, error) {
-	body, err := c.Request("POST", discord.EndpointMessageThreads(channelID, messageID), params)
+func (c *Client) StartThreadFromMessage(channelID string, messageID string, params *discord.StartThreadFromMessage, opts ...RequestOption) (*discord.Channel, error) {
+	body, err := c.Request("POST", discord.EndpointMessageThreads(channelID, messageID), params, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -373,8 +481,8 @@ func (c *Client) StartThreadFromMessage(channelID string, messageID string, para
 }
 
 // https://discord.com/developers/docs/resources/channel#start-thread-without-message
-func (c *Client) StartThreadWithoutMessage(channelID string, params *discord.StartThreadWithoutMessage) (*discord.Channel, error) {
-	body, err := c.Request("POST", discord.EndpointChannelThreads(channelID), params)
+func (c *Client) StartThreadWithoutMessage(channelID string, params *discord.StartThreadWithoutMessage, opts ...RequestOption) (*discord.Channel, error) {
+	body, err := c.Request("POST", discord.EndpointChannelThreads(channelID), params, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -386,8 +494,8 @@ func (c *Client) StartThreadWithoutMessage(channelID string, params *discord.Sta
 }
 
 // https://discord.com/developers/docs/resources/channel#start-thread-in-forum-channel
-func (c *Client) StartThreadInForumChannel(channelID string, params *discord.StartThreadInForumChannel) (*discord.ForumChannelThreadCreate, error) {
-	body, err := c.Request("POST", discord.EndpointChannelThreads(channelID), params)
+func (c *Client) StartThreadInForumChannel(channelID string, params *discord.StartThreadInForumChannel, opts ...RequestOption) (*discord.ForumChannelThreadCreate, error) {
+	body, err := c.Request("POST", discord.EndpointChannelThreads(channelID), params, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -449,8 +557,8 @@ func (c *Client) ListThreadMembers(channelID string) ([]*discord.ThreadMember, e
 }
 
 // https://discord.com/developers/docs/resources/channel#list-public-archived-threads
-func (c *Client) ListPublicArchivedThreads(channelID string, params *discord.ListArchivedThreads) (*discord.ArchivedThreads, error) {
-	body, err := c.Request("GET", discord.EndpointArchivedThreadsPublic(channelID), params)
+func (c *Client) ListPublicArchivedThreads(channelID string, params *discord.ListArchivedThreads, opts ...RequestOption) (*discord.ArchivedThreads, error) {
+	body, err := c.Request("GET", discord.EndpointArchivedThreadsPublic(channelID)+queryString(params), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -462,8 +570,8 @@ func (c *Client) ListPublicArchivedThreads(channelID string, params *discord.Lis
 }
 
 // https://discord.com/developers/docs/resources/channel#list-private-archived-threads
-func (c *Client) ListPrivateArchivedThreads(channelID string, params *discord.ListArchivedThreads) (*discord.ArchivedThreads, error) {
-	body, err := c.Request("GET", discord.EndpointArchivedThreadsPrivate(channelID), params)
+func (c *Client) ListPrivateArchivedThreads(channelID string, params *discord.ListArchivedThreads, opts ...RequestOption) (*discord.ArchivedThreads, error) {
+	body, err := c.Request("GET", discord.EndpointArchivedThreadsPrivate(channelID)+queryString(params), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -475,8 +583,8 @@ func (c *Client) ListPrivateArchivedThreads(channelID string, params *discord.Li
 }
 
 // https://discord.com/developers/docs/resources/channel#list-joined-private-archived-threads
-func (c *Client) ListJoinedPrivateArchivedThreads(channelID string, params *discord.ListArchivedThreads) (*discord.ArchivedThreads, error) {
-	body, err := c.Request("GET", discord.EndpointJoinedArchivedThreads(channelID), params)
+func (c *Client) ListJoinedPrivateArchivedThreads(channelID string, params *discord.ListArchivedThreads, opts ...RequestOption) (*discord.ArchivedThreads, error) {
+	body, err := c.Request("GET", discord.EndpointJoinedArchivedThreads(channelID)+queryString(params), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -514,8 +622,8 @@ func (c *Client) GetGuildEmoji(guildID string, emojiID string) (*discord.Emoji,
 }
 
 // https://discord.com/developers/docs/resources/emoji#create-guild-emoji
-func (c *Client) CreateGuildEmoji(guildID string, params *discord.CreateGuildEmoji) (*discord.Emoji, error) {
-	body, err := c.Request("POST", discord.EndpointGuildEmojis(guildID), params)
+func (c *Client) CreateGuildEmoji(guildID string, params *discord.CreateGuildEmoji, opts ...RequestOption) (*discord.Emoji, error) {
+	body, err := c.Request("POST", discord.EndpointGuildEmojis(guildID), params, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -527,8 +635,8 @@ func (c *Client) CreateGuildEmoji(guildID string, params *discord.CreateGuildEmo
 }
 
 // https://discord.com/developers/docs/resources/emoji#modify-guild-emoji
-func (c *Client) ModifyGuildEmoji(guildID string, emojiID string, params *discord.ModifyGuildEmoji) (*discord.Emoji, error) {
-	body, err := c.Request("PATCH", discord.EndpointGuildEmoji(guildID, emojiID), params)
+func (c *Client) ModifyGuildEmoji(guildID string, emojiID string, params *discord.ModifyGuildEmoji, opts ...RequestOption) (*discord.Emoji, error) {
+	body, err := c.Request("PATCH", discord.EndpointGuildEmoji(guildID, emojiID), params, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -540,7 +648,894 @@ func (c *Client) ModifyGuildEmoji(guildID string, emojiID string, params *discor
 }
 
 // https://discord.com/developers/docs/resources/emoji#delete-guild-emoji
-func (c *Client) DeleteGuildEmoji(guildID string, emojiID string) error {
-	_, err := c.Request("DELETE", discord.EndpointGuildEmoji(guildID, emojiID), nil)
+func (c *Client) DeleteGuildEmoji(guildID string, emojiID string, opts ...RequestOption) error {
+	_, err := c.Request("DELETE", discord.EndpointGuildEmoji(guildID, emojiID), nil, opts...)
+	return err
+}
+
+// https://discord.com/developers/docs/resources/guild#create-guild
+func (c *Client) CreateGuild(params *discord.CreateGuild) (*discord.Guild, error) {
+	body, err := c.Request("POST", discord.EndpointGuilds, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var guild discord.Guild
+	err = json.Unmarshal(body, &guild)
+
+	return &guild, err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild
+func (c *Client) GetGuild(guildID string, withCounts bool) (*discord.Guild, error) {
+	endpoint := discord.EndpointGuild(guildID)
+	if withCounts {
+		endpoint += "?with_counts=true"
+	}
+
+	body, err := c.Request("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var guild discord.Guild
+	err = json.Unmarshal(body, &guild)
+
+	return &guild, err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-preview
+func (c *Client) GetGuildPreview(guildID string) (*discord.GuildPreview, error) {
+	body, err := c.Request("GET", discord.EndpointGuildPreview(guildID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var preview discord.GuildPreview
+	err = json.Unmarshal(body, &preview)
+
+	return &preview, err
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild
+func (c *Client) ModifyGuild(guildID string, params *discord.ModifyGuild, opts ...RequestOption) (*discord.Guild, error) {
+	body, err := c.Request("PATCH", discord.EndpointGuild(guildID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var guild discord.Guild
+	err = json.Unmarshal(body, &guild)
+
+	return &guild, err
+}
+
+// https://discord.com/developers/docs/resources/guild#delete-guild
+func (c *Client) DeleteGuild(guildID string) error {
+	_, err := c.Request("DELETE", discord.EndpointGuild(guildID), nil)
+	return err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-channels
+func (c *Client) GetGuildChannels(guildID string) ([]*discord.Channel, error) {
+	body, err := c.Request("GET", discord.EndpointGuildChannels(guildID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var channels []*discord.Channel
+	err = json.Unmarshal(body, &channels)
+
+	return channels, err
+}
+
+// https://discord.com/developers/docs/resources/guild#create-guild-channel
+func (c *Client) CreateGuildChannel(guildID string, params *discord.CreateGuildChannel, opts ...RequestOption) (*discord.Channel, error) {
+	body, err := c.Request("POST", discord.EndpointGuildChannels(guildID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var channel discord.Channel
+	err = json.Unmarshal(body, &channel)
+
+	return &channel, err
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-channel-positions
+func (c *Client) ModifyGuildChannelPositions(guildID string, params []*discord.ModifyGuildChannelPosition) error {
+	_, err := c.Request("PATCH", discord.EndpointGuildChannels(guildID), params)
+	return err
+}
+
+// https://discord.com/developers/docs/resources/guild#list-active-guild-threads
+func (c *Client) ListActiveGuildThreads(guildID string) (*discord.ActiveThreads, error) {
+	body, err := c.Request("GET", discord.EndpointGuildActiveThreads(guildID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var threads discord.ActiveThreads
+	err = json.Unmarshal(body, &threads)
+
+	return &threads, err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-member
+func (c *Client) GetGuildMember(guildID string, userID string) (*discord.GuildMember, error) {
+	body, err := c.Request("GET", discord.EndpointGuildMember(guildID, userID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var member discord.GuildMember
+	err = json.Unmarshal(body, &member)
+
+	return &member, err
+}
+
+// https://discord.com/developers/docs/resources/guild#list-guild-members
+func (c *Client) ListGuildMembers(guildID string, params *discord.ListGuildMembers) ([]*discord.GuildMember, error) {
+	body, err := c.Request("GET", discord.EndpointGuildMembers(guildID)+queryString(params), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []*discord.GuildMember
+	err = json.Unmarshal(body, &members)
+
+	return members, err
+}
+
+// https://discord.com/developers/docs/resources/guild#search-guild-members
+func (c *Client) SearchGuildMembers(guildID string, params *discord.SearchGuildMembers) ([]*discord.GuildMember, error) {
+	body, err := c.Request("GET", discord.EndpointGuildMembersSearch(guildID)+queryString(params), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []*discord.GuildMember
+	err = json.Unmarshal(body, &members)
+
+	return members, err
+}
+
+// https://discord.com/developers/docs/resources/guild#add-guild-member
+// Returns a nil member if the user is already a member of the guild
+func (c *Client) AddGuildMember(guildID string, userID string, params *discord.AddGuildMember) (*discord.GuildMember, error) {
+	body, err := c.Request("PUT", discord.EndpointGuildMember(guildID, userID), params)
+	if err != nil || len(body) == 0 {
+		return nil, err
+	}
+
+	var member discord.GuildMember
+	err = json.Unmarshal(body, &member)
+
+	return &member, err
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-member
+func (c *Client) ModifyGuildMember(guildID string, userID string, params *discord.ModifyGuildMember, opts ...RequestOption) (*discord.GuildMember, error) {
+	body, err := c.Request("PATCH", discord.EndpointGuildMember(guildID, userID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var member discord.GuildMember
+	err = json.Unmarshal(body, &member)
+
+	return &member, err
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-current-member
+func (c *Client) ModifyCurrentMember(guildID string, params *discord.ModifyCurrentMember, opts ...RequestOption) (*discord.GuildMember, error) {
+	body, err := c.Request("PATCH", discord.EndpointGuildMemberSelf(guildID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var member discord.GuildMember
+	err = json.Unmarshal(body, &member)
+
+	return &member, err
+}
+
+// https://discord.com/developers/docs/resources/guild#add-guild-member-role
+func (c *Client) AddGuildMemberRole(guildID string, userID string, roleID string, opts ...RequestOption) error {
+	_, err := c.Request("PUT", discord.EndpointGuildMemberRole(guildID, userID, roleID), nil, opts...)
+	return err
+}
+
+// https://discord.com/developers/docs/resources/guild#remove-guild-member-role
+func (c *Client) RemoveGuildMemberRole(guildID string, userID string, roleID string, opts ...RequestOption) error {
+	_, err := c.Request("DELETE", discord.EndpointGuildMemberRole(guildID, userID, roleID), nil, opts...)
+	return err
+}
+
+// https://discord.com/developers/docs/resources/guild#remove-guild-member
+func (c *Client) RemoveGuildMember(guildID string, userID string, opts ...RequestOption) error {
+	_, err := c.Request("DELETE", discord.EndpointGuildMember(guildID, userID), nil, opts...)
+	return err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-bans
+func (c *Client) GetGuildBans(guildID string, params *discord.GetGuildBans, opts ...RequestOption) ([]*discord.Ban, error) {
+	body, err := c.Request("GET", discord.EndpointGuildBans(guildID)+queryString(params), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var bans []*discord.Ban
+	err = json.Unmarshal(body, &bans)
+
+	return bans, err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-ban
+func (c *Client) GetGuildBan(guildID string, userID string) (*discord.Ban, error) {
+	body, err := c.Request("GET", discord.EndpointGuildBan(guildID, userID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ban discord.Ban
+	err = json.Unmarshal(body, &ban)
+
+	return &ban, err
+}
+
+// https://discord.com/developers/docs/resources/guild#create-guild-ban
+func (c *Client) CreateGuildBan(guildID string, userID string, params *discord.CreateGuildBan, opts ...RequestOption) error {
+	_, err := c.Request("PUT", discord.EndpointGuildBan(guildID, userID), params, opts...)
+	return err
+}
+
+// https://discord.com/developers/docs/resources/guild#remove-guild-ban
+func (c *Client) RemoveGuildBan(guildID string, userID string, opts ...RequestOption) error {
+	_, err := c.Request("DELETE", discord.EndpointGuildBan(guildID, userID), nil, opts...)
+	return err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-roles
+func (c *Client) GetGuildRoles(guildID string) ([]*discord.Role, error) {
+	body, err := c.Request("GET", discord.EndpointGuildRoles(guildID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []*discord.Role
+	err = json.Unmarshal(body, &roles)
+
+	return roles, err
+}
+
+// https://discord.com/developers/docs/resources/guild#create-guild-role
+func (c *Client) CreateGuildRole(guildID string, params *discord.CreateGuildRole, opts ...RequestOption) (*discord.Role, error) {
+	body, err := c.Request("POST", discord.EndpointGuildRoles(guildID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var role discord.Role
+	err = json.Unmarshal(body, &role)
+
+	return &role, err
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-role-positions
+func (c *Client) ModifyGuildRolePositions(guildID string, params []*discord.ModifyGuildRolePosition, opts ...RequestOption) ([]*discord.Role, error) {
+	body, err := c.Request("PATCH", discord.EndpointGuildRoles(guildID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []*discord.Role
+	err = json.Unmarshal(body, &roles)
+
+	return roles, err
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-role
+func (c *Client) ModifyGuildRole(guildID string, roleID string, params *discord.ModifyGuildRole, opts ...RequestOption) (*discord.Role, error) {
+	body, err := c.Request("PATCH", discord.EndpointGuildRole(guildID, roleID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var role discord.Role
+	err = json.Unmarshal(body, &role)
+
+	return &role, err
+}
+
+// https://discord.com/developers/docs/resources/guild#delete-guild-role
+func (c *Client) DeleteGuildRole(guildID string, roleID string, opts ...RequestOption) error {
+	_, err := c.Request("DELETE", discord.EndpointGuildRole(guildID, roleID), nil, opts...)
+	return err
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-mfa-level
+func (c *Client) ModifyGuildMFALevel(guildID string, params *discord.ModifyGuildMFALevel, opts ...RequestOption) (discord.MFALevel, error) {
+	body, err := c.Request("POST", discord.EndpointGuildMFA(guildID), params, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	var level discord.ModifyGuildMFALevel
+	err = json.Unmarshal(body, &level)
+
+	return level.Level, err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-prune-count
+func (c *Client) GetGuildPruneCount(guildID string, params *discord.GetGuildPruneCount) (int, error) {
+	body, err := c.Request("GET", discord.EndpointGuildPrune(guildID)+queryString(params), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var prune discord.GuildPrune
+	err = json.Unmarshal(body, &prune)
+
+	return prune.Pruned, err
+}
+
+// https://discord.com/developers/docs/resources/guild#begin-guild-prune
+func (c *Client) BeginGuildPrune(guildID string, params *discord.BeginGuildPrune, opts ...RequestOption) (int, error) {
+	body, err := c.Request("POST", discord.EndpointGuildPrune(guildID), params, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	var prune discord.GuildPrune
+	err = json.Unmarshal(body, &prune)
+
+	return prune.Pruned, err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-voice-regions
+func (c *Client) GetGuildVoiceRegions(guildID string) ([]*discord.VoiceRegion, error) {
+	body, err := c.Request("GET", discord.EndpointGuildVoiceRegions(guildID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var regions []*discord.VoiceRegion
+	err = json.Unmarshal(body, &regions)
+
+	return regions, err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-invites
+func (c *Client) GetGuildInvites(guildID string) ([]*discord.Invite, error) {
+	body, err := c.Request("GET", discord.EndpointGuildInvites(guildID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var invites []*discord.Invite
+	err = json.Unmarshal(body, &invites)
+
+	return invites, err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-integrations
+func (c *Client) GetGuildIntegrations(guildID string) ([]*discord.Integration, error) {
+	body, err := c.Request("GET", discord.EndpointGuildIntegrations(guildID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var integrations []*discord.Integration
+	err = json.Unmarshal(body, &integrations)
+
+	return integrations, err
+}
+
+// https://discord.com/developers/docs/resources/guild#delete-guild-integration
+func (c *Client) DeleteGuildIntegration(guildID string, integrationID string, opts ...RequestOption) error {
+	_, err := c.Request("DELETE", discord.EndpointGuildIntegration(guildID, integrationID), nil, opts...)
+	return err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-widget-settings
+func (c *Client) GetGuildWidgetSettings(guildID string) (*discord.GuildWidgetSettings, error) {
+	body, err := c.Request("GET", discord.EndpointGuildWidgetSettings(guildID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings discord.GuildWidgetSettings
+	err = json.Unmarshal(body, &settings)
+
+	return &settings, err
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-widget
+func (c *Client) ModifyGuildWidget(guildID string, params *discord.GuildWidgetSettings, opts ...RequestOption) (*discord.GuildWidgetSettings, error) {
+	body, err := c.Request("PATCH", discord.EndpointGuildWidgetSettings(guildID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings discord.GuildWidgetSettings
+	err = json.Unmarshal(body, &settings)
+
+	return &settings, err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-widget
+func (c *Client) GetGuildWidget(guildID string) (*discord.GetGuildWidget, error) {
+	body, err := c.Request("GET", discord.EndpointGuildWidget(guildID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var widget discord.GetGuildWidget
+	err = json.Unmarshal(body, &widget)
+
+	return &widget, err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-vanity-url
+func (c *Client) GetGuildVanityURL(guildID string) (*discord.GuildVanityURL, error) {
+	body, err := c.Request("GET", discord.EndpointGuildVanityURL(guildID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var vanity discord.GuildVanityURL
+	err = json.Unmarshal(body, &vanity)
+
+	return &vanity, err
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-welcome-screen
+func (c *Client) GetGuildWelcomeScreen(guildID string) (*discord.WelcomeScreen, error) {
+	body, err := c.Request("GET", discord.EndpointGuildWelcomeScreen(guildID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var screen discord.WelcomeScreen
+	err = json.Unmarshal(body, &screen)
+
+	return &screen, err
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-guild-welcome-screen
+func (c *Client) ModifyGuildWelcomeScreen(guildID string, params *discord.ModifyGuildWelcomeScreen, opts ...RequestOption) (*discord.WelcomeScreen, error) {
+	body, err := c.Request("PATCH", discord.EndpointGuildWelcomeScreen(guildID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var screen discord.WelcomeScreen
+	err = json.Unmarshal(body, &screen)
+
+	return &screen, err
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-current-user-voice-state
+func (c *Client) ModifyCurrentUserVoiceState(guildID string, params *discord.ModifyCurrentUserVoiceState) error {
+	_, err := c.Request("PATCH", discord.EndpointGuildVoiceStateSelf(guildID), params)
+	return err
+}
+
+// https://discord.com/developers/docs/resources/guild#modify-user-voice-state
+func (c *Client) ModifyUserVoiceState(guildID string, userID string, params *discord.ModifyUserVoiceState) error {
+	_, err := c.Request("PATCH", discord.EndpointGuildVoiceState(guildID, userID), params)
+	return err
+}
+
+// https://discord.com/developers/docs/resources/webhook#create-webhook
+func (c *Client) CreateWebhook(channelID string, params *discord.CreateWebhook, opts ...RequestOption) (*discord.Webhook, error) {
+	body, err := c.Request("POST", discord.EndpointChannelWebhooks(channelID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook discord.Webhook
+	err = json.Unmarshal(body, &webhook)
+
+	return &webhook, err
+}
+
+// https://discord.com/developers/docs/resources/webhook#get-channel-webhooks
+func (c *Client) GetChannelWebhooks(channelID string) ([]*discord.Webhook, error) {
+	body, err := c.Request("GET", discord.EndpointChannelWebhooks(channelID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhooks []*discord.Webhook
+	err = json.Unmarshal(body, &webhooks)
+
+	return webhooks, err
+}
+
+// https://discord.com/developers/docs/resources/webhook#get-guild-webhooks
+func (c *Client) GetGuildWebhooks(guildID string) ([]*discord.Webhook, error) {
+	body, err := c.Request("GET", discord.EndpointGuildWebhooks(guildID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhooks []*discord.Webhook
+	err = json.Unmarshal(body, &webhooks)
+
+	return webhooks, err
+}
+
+// https://discord.com/developers/docs/resources/webhook#get-webhook
+func (c *Client) GetWebhook(webhookID string) (*discord.Webhook, error) {
+	body, err := c.Request("GET", discord.EndpointWebhook(webhookID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook discord.Webhook
+	err = json.Unmarshal(body, &webhook)
+
+	return &webhook, err
+}
+
+// https://discord.com/developers/docs/resources/webhook#get-webhook-with-token
+func (c *Client) GetWebhookWithToken(webhookID string, token string) (*discord.Webhook, error) {
+	body, err := c.Request("GET", discord.EndpointWebhookWithToken(webhookID, token), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook discord.Webhook
+	err = json.Unmarshal(body, &webhook)
+
+	return &webhook, err
+}
+
+// https://discord.com/developers/docs/resources/webhook#modify-webhook
+func (c *Client) ModifyWebhook(webhookID string, params *discord.ModifyWebhook, opts ...RequestOption) (*discord.Webhook, error) {
+	body, err := c.Request("PATCH", discord.EndpointWebhook(webhookID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook discord.Webhook
+	err = json.Unmarshal(body, &webhook)
+
+	return &webhook, err
+}
+
+// https://discord.com/developers/docs/resources/webhook#modify-webhook-with-token
+func (c *Client) ModifyWebhookWithToken(webhookID string, token string, params *discord.ModifyWebhook, opts ...RequestOption) (*discord.Webhook, error) {
+	body, err := c.Request("PATCH", discord.EndpointWebhookWithToken(webhookID, token), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook discord.Webhook
+	err = json.Unmarshal(body, &webhook)
+
+	return &webhook, err
+}
+
+// https://discord.com/developers/docs/resources/webhook#delete-webhook
+func (c *Client) DeleteWebhook(webhookID string, opts ...RequestOption) error {
+	_, err := c.Request("DELETE", discord.EndpointWebhook(webhookID), nil, opts...)
+	return err
+}
+
+// https://discord.com/developers/docs/resources/webhook#delete-webhook-with-token
+func (c *Client) DeleteWebhookWithToken(webhookID string, token string, opts ...RequestOption) error {
+	_, err := c.Request("DELETE", discord.EndpointWebhookWithToken(webhookID, token), nil, opts...)
+	return err
+}
+
+// https://discord.com/developers/docs/resources/webhook#execute-webhook
+// The returned message is nil unless query.Wait is true
+func (c *Client) ExecuteWebhook(webhookID string, token string, params *discord.ExecuteWebhook, query *discord.ExecuteWebhookQuery) (*discord.Message, error) {
+	body, err := c.Request("POST", discord.EndpointWebhookWithToken(webhookID, token)+queryString(query), params)
+	if err != nil || len(body) == 0 {
+		return nil, err
+	}
+
+	var message discord.Message
+	err = json.Unmarshal(body, &message)
+
+	return &message, err
+}
+
+// https://discord.com/developers/docs/resources/webhook#get-webhook-message
+func (c *Client) GetWebhookMessage(webhookID string, token string, messageID string, threadID string) (*discord.Message, error) {
+	query := queryString(&discord.ExecuteWebhookQuery{ThreadID: threadID})
+	body, err := c.Request("GET", discord.EndpointWebhookMessage(webhookID, token, messageID)+query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var message discord.Message
+	err = json.Unmarshal(body, &message)
+
+	return &message, err
+}
+
+// https://discord.com/developers/docs/resources/webhook#edit-webhook-message
+func (c *Client) EditWebhookMessage(webhookID string, token string, messageID string, threadID string, params *discord.EditWebhookMessage) (*discord.Message, error) {
+	query := queryString(&discord.ExecuteWebhookQuery{ThreadID: threadID})
+	body, err := c.Request("PATCH", discord.EndpointWebhookMessage(webhookID, token, messageID)+query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var message discord.Message
+	err = json.Unmarshal(body, &message)
+
+	return &message, err
+}
+
+// https://discord.com/developers/docs/resources/webhook#delete-webhook-message
+func (c *Client) DeleteWebhookMessage(webhookID string, token string, messageID string, threadID string) error {
+	query := queryString(&discord.ExecuteWebhookQuery{ThreadID: threadID})
+	_, err := c.Request("DELETE", discord.EndpointWebhookMessage(webhookID, token, messageID)+query, nil)
+	return err
+}
+
+// https://discord.com/developers/docs/topics/oauth2#get-current-bot-application-information
+func (c *Client) GetCurrentBotApplicationInformation() (*discord.Application, error) {
+	body, err := c.Request("GET", discord.EndpointCurrentApplication, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var application discord.Application
+	err = json.Unmarshal(body, &application)
+
+	return &application, err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#get-global-application-commands
+func (c *Client) GetGlobalApplicationCommands(applicationID string, params *discord.GetApplicationCommands, opts ...RequestOption) ([]*discord.ApplicationCommand, error) {
+	body, err := c.Request("GET", discord.EndpointGlobalCommands(applicationID)+queryString(params), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var commands []*discord.ApplicationCommand
+	err = json.Unmarshal(body, &commands)
+
+	return commands, err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#create-global-application-command
+func (c *Client) CreateGlobalApplicationCommand(applicationID string, params *discord.CreateApplicationCommand, opts ...RequestOption) (*discord.ApplicationCommand, error) {
+	body, err := c.Request("POST", discord.EndpointGlobalCommands(applicationID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var command discord.ApplicationCommand
+	err = json.Unmarshal(body, &command)
+
+	return &command, err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#get-global-application-command
+func (c *Client) GetGlobalApplicationCommand(applicationID string, commandID string) (*discord.ApplicationCommand, error) {
+	body, err := c.Request("GET", discord.EndpointGlobalCommand(applicationID, commandID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var command discord.ApplicationCommand
+	err = json.Unmarshal(body, &command)
+
+	return &command, err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#edit-global-application-command
+func (c *Client) EditGlobalApplicationCommand(applicationID string, commandID string, params *discord.EditApplicationCommand, opts ...RequestOption) (*discord.ApplicationCommand, error) {
+	body, err := c.Request("PATCH", discord.EndpointGlobalCommand(applicationID, commandID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var command discord.ApplicationCommand
+	err = json.Unmarshal(body, &command)
+
+	return &command, err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#delete-global-application-command
+func (c *Client) DeleteGlobalApplicationCommand(applicationID string, commandID string, opts ...RequestOption) error {
+	_, err := c.Request("DELETE", discord.EndpointGlobalCommand(applicationID, commandID), nil, opts...)
+	return err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#bulk-overwrite-global-application-commands
+func (c *Client) BulkOverwriteGlobalApplicationCommands(applicationID string, params []*discord.CreateApplicationCommand) ([]*discord.ApplicationCommand, error) {
+	body, err := c.Request("PUT", discord.EndpointGlobalCommands(applicationID), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var commands []*discord.ApplicationCommand
+	err = json.Unmarshal(body, &commands)
+
+	return commands, err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#get-guild-application-commands
+func (c *Client) GetGuildApplicationCommands(applicationID string, guildID string, params *discord.GetApplicationCommands, opts ...RequestOption) ([]*discord.ApplicationCommand, error) {
+	body, err := c.Request("GET", discord.EndpointGuildCommands(applicationID, guildID)+queryString(params), nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var commands []*discord.ApplicationCommand
+	err = json.Unmarshal(body, &commands)
+
+	return commands, err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#create-guild-application-command
+func (c *Client) CreateGuildApplicationCommand(applicationID string, guildID string, params *discord.CreateApplicationCommand, opts ...RequestOption) (*discord.ApplicationCommand, error) {
+	body, err := c.Request("POST", discord.EndpointGuildCommands(applicationID, guildID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var command discord.ApplicationCommand
+	err = json.Unmarshal(body, &command)
+
+	return &command, err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#get-guild-application-command
+func (c *Client) GetGuildApplicationCommand(applicationID string, guildID string, commandID string) (*discord.ApplicationCommand, error) {
+	body, err := c.Request("GET", discord.EndpointGuildCommand(applicationID, guildID, commandID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var command discord.ApplicationCommand
+	err = json.Unmarshal(body, &command)
+
+	return &command, err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#edit-guild-application-command
+func (c *Client) EditGuildApplicationCommand(applicationID string, guildID string, commandID string, params *discord.EditApplicationCommand, opts ...RequestOption) (*discord.ApplicationCommand, error) {
+	body, err := c.Request("PATCH", discord.EndpointGuildCommand(applicationID, guildID, commandID), params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var command discord.ApplicationCommand
+	err = json.Unmarshal(body, &command)
+
+	return &command, err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#delete-guild-application-command
+func (c *Client) DeleteGuildApplicationCommand(applicationID string, guildID string, commandID string, opts ...RequestOption) error {
+	_, err := c.Request("DELETE", discord.EndpointGuildCommand(applicationID, guildID, commandID), nil, opts...)
+	return err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#bulk-overwrite-guild-application-commands
+func (c *Client) BulkOverwriteGuildApplicationCommands(applicationID string, guildID string, params []*discord.CreateApplicationCommand) ([]*discord.ApplicationCommand, error) {
+	body, err := c.Request("PUT", discord.EndpointGuildCommands(applicationID, guildID), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var commands []*discord.ApplicationCommand
+	err = json.Unmarshal(body, &commands)
+
+	return commands, err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#get-guild-application-command-permissions
+func (c *Client) GetGuildApplicationCommandPermissions(applicationID string, guildID string) ([]*discord.GuildApplicationCommandPermissions, error) {
+	body, err := c.Request("GET", discord.EndpointGuildCommandsPermissions(applicationID, guildID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions []*discord.GuildApplicationCommandPermissions
+	err = json.Unmarshal(body, &permissions)
+
+	return permissions, err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#get-application-command-permissions
+func (c *Client) GetApplicationCommandPermissions(applicationID string, guildID string, commandID string) (*discord.GuildApplicationCommandPermissions, error) {
+	body, err := c.Request("GET", discord.EndpointCommandPermissions(applicationID, guildID, commandID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions discord.GuildApplicationCommandPermissions
+	err = json.Unmarshal(body, &permissions)
+
+	return &permissions, err
+}
+
+// https://discord.com/developers/docs/interactions/application-commands#edit-application-command-permissions
+func (c *Client) EditApplicationCommandPermissions(applicationID string, guildID string, commandID string, params *discord.EditApplicationCommandPermissions) (*discord.GuildApplicationCommandPermissions, error) {
+	body, err := c.Request("PUT", discord.EndpointCommandPermissions(applicationID, guildID, commandID), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions discord.GuildApplicationCommandPermissions
+	err = json.Unmarshal(body, &permissions)
+
+	return &permissions, err
+}
+
+// https://discord.com/developers/docs/interactions/receiving-and-responding#create-interaction-response
+func (c *Client) CreateInteractionResponse(interactionID string, token string, params *discord.InteractionResponse) error {
+	_, err := c.Request("POST", discord.EndpointInteractionCallback(interactionID, token), params)
+	return err
+}
+
+// https://discord.com/developers/docs/interactions/receiving-and-responding#get-original-interaction-response
+func (c *Client) GetOriginalInteractionResponse(applicationID string, token string) (*discord.Message, error) {
+	return c.GetFollowupMessage(applicationID, token, "@original")
+}
+
+// https://discord.com/developers/docs/interactions/receiving-and-responding#edit-original-interaction-response
+func (c *Client) EditOriginalInteractionResponse(applicationID string, token string, params *discord.EditWebhookMessage) (*discord.Message, error) {
+	return c.EditFollowupMessage(applicationID, token, "@original", params)
+}
+
+// https://discord.com/developers/docs/interactions/receiving-and-responding#delete-original-interaction-response
+func (c *Client) DeleteOriginalInteractionResponse(applicationID string, token string) error {
+	return c.DeleteFollowupMessage(applicationID, token, "@original")
+}
+
+// https://discord.com/developers/docs/interactions/receiving-and-responding#create-followup-message
+func (c *Client) CreateFollowupMessage(applicationID string, token string, params *discord.ExecuteWebhook) (*discord.Message, error) {
+	body, err := c.Request("POST", discord.EndpointWebhookWithToken(applicationID, token), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var message discord.Message
+	err = json.Unmarshal(body, &message)
+
+	return &message, err
+}
+
+// https://discord.com/developers/docs/interactions/receiving-and-responding#get-followup-message
+func (c *Client) GetFollowupMessage(applicationID string, token string, messageID string) (*discord.Message, error) {
+	body, err := c.Request("GET", discord.EndpointWebhookMessage(applicationID, token, messageID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var message discord.Message
+	err = json.Unmarshal(body, &message)
+
+	return &message, err
+}
+
+// https://discord.com/developers/docs/interactions/receiving-and-responding#edit-followup-message
+func (c *Client) EditFollowupMessage(applicationID string, token string, messageID string, params *discord.EditWebhookMessage) (*discord.Message, error) {
+	body, err := c.Request("PATCH", discord.EndpointWebhookMessage(applicationID, token, messageID), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var message discord.Message
+	err = json.Unmarshal(body, &message)
+
+	return &message, err
+}
+
+// https://discord.com/developers/docs/interactions/receiving-and-responding#delete-followup-message
+func (c *Client) DeleteFollowupMessage(applicationID string, token string, messageID string) error {
+	_, err := c.Request("DELETE", discord.EndpointWebhookMessage(applicationID, token, messageID), nil)
 	return err
 }
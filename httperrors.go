@@ -0,0 +1,287 @@
+package eventide
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A Discord JSON error code, which can be compared against HTTP errors with errors.Is
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes
+type ErrorCode int
+
+func (c ErrorCode) Error() string {
+	return fmt.Sprintf("discord error code %d", int(c))
+}
+
+const (
+	ErrGeneral ErrorCode = 0
+
+	ErrUnknownAccount                        ErrorCode = 10001
+	ErrUnknownApplication                    ErrorCode = 10002
+	ErrUnknownChannel                        ErrorCode = 10003
+	ErrUnknownGuild                          ErrorCode = 10004
+	ErrUnknownIntegration                    ErrorCode = 10005
+	ErrUnknownInvite                         ErrorCode = 10006
+	ErrUnknownMember                         ErrorCode = 10007
+	ErrUnknownMessage                        ErrorCode = 10008
+	ErrUnknownPermissionOverwrite            ErrorCode = 10009
+	ErrUnknownProvider                       ErrorCode = 10010
+	ErrUnknownRole                           ErrorCode = 10011
+	ErrUnknownToken                          ErrorCode = 10012
+	ErrUnknownUser                           ErrorCode = 10013
+	ErrUnknownEmoji                          ErrorCode = 10014
+	ErrUnknownWebhook                        ErrorCode = 10015
+	ErrUnknownWebhookService                 ErrorCode = 10016
+	ErrUnknownSession                        ErrorCode = 10020
+	ErrUnknownBan                            ErrorCode = 10026
+	ErrUnknownSKU                            ErrorCode = 10027
+	ErrUnknownStoreListing                   ErrorCode = 10028
+	ErrUnknownEntitlement                    ErrorCode = 10029
+	ErrUnknownBuild                          ErrorCode = 10030
+	ErrUnknownLobby                          ErrorCode = 10031
+	ErrUnknownBranch                         ErrorCode = 10032
+	ErrUnknownStoreDirectoryLayout           ErrorCode = 10033
+	ErrUnknownRedistributable                ErrorCode = 10036
+	ErrUnknownGiftCode                       ErrorCode = 10038
+	ErrUnknownStream                         ErrorCode = 10049
+	ErrUnknownPremiumServerSubscribeCooldown ErrorCode = 10050
+	ErrUnknownGuildTemplate                  ErrorCode = 10057
+	ErrUnknownDiscoverableServerCategory     ErrorCode = 10059
+	ErrUnknownSticker                        ErrorCode = 10060
+	ErrUnknownInteraction                    ErrorCode = 10062
+	ErrUnknownApplicationCommand             ErrorCode = 10063
+	ErrUnknownVoiceState                     ErrorCode = 10065
+	ErrUnknownApplicationCommandPermissions  ErrorCode = 10066
+	ErrUnknownStageInstance                  ErrorCode = 10067
+	ErrUnknownGuildMemberVerificationForm    ErrorCode = 10068
+	ErrUnknownGuildWelcomeScreen             ErrorCode = 10069
+	ErrUnknownGuildScheduledEvent            ErrorCode = 10070
+	ErrUnknownGuildScheduledEventUser        ErrorCode = 10071
+
+	ErrBotsCannotUseEndpoint       ErrorCode = 20001
+	ErrOnlyBotsCanUseEndpoint      ErrorCode = 20002
+	ErrExplicitContentCannotBeSent ErrorCode = 20009
+	ErrNotAuthorizedForApplication ErrorCode = 20012
+	ErrSlowmodeRateLimit           ErrorCode = 20016
+	ErrOnlyOwner                   ErrorCode = 20018
+	ErrAnnouncementEditRateLimit   ErrorCode = 20022
+	ErrUnderMinimumAge             ErrorCode = 20024
+	ErrChannelWriteRateLimit       ErrorCode = 20028
+	ErrServerWriteRateLimit        ErrorCode = 20029
+	ErrDisallowedWords             ErrorCode = 20031
+	ErrGuildPremiumTierTooLow      ErrorCode = 20035
+
+	ErrMaxGuilds                          ErrorCode = 30001
+	ErrMaxFriends                         ErrorCode = 30002
+	ErrMaxPins                            ErrorCode = 30003
+	ErrMaxRecipients                      ErrorCode = 30004
+	ErrMaxRoles                           ErrorCode = 30005
+	ErrMaxWebhooks                        ErrorCode = 30007
+	ErrMaxEmojis                          ErrorCode = 30008
+	ErrMaxReactions                       ErrorCode = 30010
+	ErrMaxChannels                        ErrorCode = 30013
+	ErrMaxAttachments                     ErrorCode = 30015
+	ErrMaxInvites                         ErrorCode = 30016
+	ErrMaxAnimatedEmojis                  ErrorCode = 30018
+	ErrMaxServerMembers                   ErrorCode = 30019
+	ErrMaxServerCategories                ErrorCode = 30030
+	ErrGuildAlreadyHasTemplate            ErrorCode = 30031
+	ErrMaxThreadParticipants              ErrorCode = 30033
+	ErrMaxBans                            ErrorCode = 30035
+	ErrMaxBanFetches                      ErrorCode = 30037
+	ErrMaxUncompletedGuildScheduledEvents ErrorCode = 30038
+	ErrMaxStickers                        ErrorCode = 30039
+	ErrMaxPruneRequests                   ErrorCode = 30040
+	ErrMaxGuildWidgetSettingsUpdates      ErrorCode = 30042
+	ErrMaxEditsToOldMessages              ErrorCode = 30046
+	ErrMaxPinnedThreads                   ErrorCode = 30047
+	ErrMaxForumTags                       ErrorCode = 30048
+
+	ErrUnauthorized                   ErrorCode = 40001
+	ErrAccountVerificationRequired    ErrorCode = 40002
+	ErrDirectMessagesTooFast          ErrorCode = 40003
+	ErrRequestEntityTooLarge          ErrorCode = 40005
+	ErrFeatureTemporarilyDisabled     ErrorCode = 40006
+	ErrUserBannedFromGuild            ErrorCode = 40007
+	ErrTargetUserNotConnectedToVoice  ErrorCode = 40032
+	ErrMessageAlreadyCrossposted      ErrorCode = 40033
+	ErrApplicationCommandNameExists   ErrorCode = 40041
+	ErrInteractionAlreadyAcknowledged ErrorCode = 40060
+	ErrTagNamesMustBeUnique           ErrorCode = 40061
+
+	ErrMissingAccess                     ErrorCode = 50001
+	ErrInvalidAccountType                ErrorCode = 50002
+	ErrCannotExecuteOnDMChannel          ErrorCode = 50003
+	ErrGuildWidgetDisabled               ErrorCode = 50004
+	ErrCannotEditOtherUsersMessage       ErrorCode = 50005
+	ErrCannotSendEmptyMessage            ErrorCode = 50006
+	ErrCannotMessageUser                 ErrorCode = 50007
+	ErrCannotSendInVoiceChannel          ErrorCode = 50008
+	ErrChannelVerificationTooHigh        ErrorCode = 50009
+	ErrOAuth2ApplicationNoBot            ErrorCode = 50010
+	ErrOAuth2ApplicationLimit            ErrorCode = 50011
+	ErrInvalidOAuth2State                ErrorCode = 50012
+	ErrMissingPermissions                ErrorCode = 50013
+	ErrInvalidToken                      ErrorCode = 50014
+	ErrNoteTooLong                       ErrorCode = 50015
+	ErrInvalidBulkDeleteCount            ErrorCode = 50016
+	ErrInvalidMFALevel                   ErrorCode = 50017
+	ErrCannotPinInOtherChannel           ErrorCode = 50019
+	ErrInvalidInviteCode                 ErrorCode = 50020
+	ErrCannotExecuteOnSystemMessage      ErrorCode = 50021
+	ErrCannotExecuteOnChannelType        ErrorCode = 50024
+	ErrInvalidOAuth2AccessToken          ErrorCode = 50025
+	ErrMissingOAuth2Scope                ErrorCode = 50026
+	ErrInvalidWebhookToken               ErrorCode = 50027
+	ErrInvalidRole                       ErrorCode = 50028
+	ErrInvalidRecipients                 ErrorCode = 50033
+	ErrBulkDeleteMessageTooOld           ErrorCode = 50034
+	ErrInvalidFormBody                   ErrorCode = 50035
+	ErrInviteAcceptedToGuildWithoutBot   ErrorCode = 50036
+	ErrInvalidActivityAction             ErrorCode = 50039
+	ErrInvalidAPIVersion                 ErrorCode = 50041
+	ErrFileUploadTooLarge                ErrorCode = 50045
+	ErrInvalidFileUploaded               ErrorCode = 50046
+	ErrCannotSelfRedeemGift              ErrorCode = 50054
+	ErrInvalidGuild                      ErrorCode = 50055
+	ErrInvalidMessageType                ErrorCode = 50068
+	ErrPaymentSourceRequired             ErrorCode = 50070
+	ErrCannotDeleteCommunityChannel      ErrorCode = 50074
+	ErrCannotEditStickerMessage          ErrorCode = 50080
+	ErrInvalidStickerSent                ErrorCode = 50081
+	ErrThreadArchived                    ErrorCode = 50083
+	ErrInvalidThreadNotificationSettings ErrorCode = 50084
+	ErrBeforeEarlierThanThreadCreation   ErrorCode = 50085
+	ErrCommunityChannelsMustBeText       ErrorCode = 50086
+	ErrServerNotAvailableInLocation      ErrorCode = 50095
+	ErrMonetizationRequired              ErrorCode = 50097
+	ErrBoostsRequired                    ErrorCode = 50101
+	ErrInvalidJSON                       ErrorCode = 50109
+	ErrOwnershipCannotBeTransferredToBot ErrorCode = 50132
+	ErrFailedToResizeAsset               ErrorCode = 50138
+	ErrUploadedFileNotFound              ErrorCode = 50146
+	ErrNoPermissionToSendSticker         ErrorCode = 50600
+
+	ErrTwoFactorRequired ErrorCode = 60003
+
+	ErrNoUsersWithDiscordTag ErrorCode = 80004
+
+	ErrReactionBlocked ErrorCode = 90001
+
+	ErrApplicationNotAvailable ErrorCode = 110001
+
+	ErrAPIResourceOverloaded ErrorCode = 130000
+
+	ErrStageAlreadyOpen ErrorCode = 150006
+
+	ErrCannotReplyWithoutReadHistory ErrorCode = 160002
+	ErrThreadAlreadyCreated          ErrorCode = 160004
+	ErrThreadLocked                  ErrorCode = 160005
+	ErrMaxActiveThreads              ErrorCode = 160006
+	ErrMaxActiveAnnouncementThreads  ErrorCode = 160007
+
+	ErrInvalidLottieJSON          ErrorCode = 170001
+	ErrLottieRasterizedImages     ErrorCode = 170002
+	ErrStickerMaxFramerate        ErrorCode = 170003
+	ErrStickerMaxFrames           ErrorCode = 170004
+	ErrLottieMaxDimensions        ErrorCode = 170005
+	ErrStickerFramerateOutOfRange ErrorCode = 170006
+	ErrStickerAnimationTooLong    ErrorCode = 170007
+
+	ErrCannotUpdateFinishedEvent   ErrorCode = 180000
+	ErrFailedToCreateStageForEvent ErrorCode = 180002
+
+	ErrMessageBlockedByAutoModeration ErrorCode = 200000
+	ErrTitleBlockedByAutoModeration   ErrorCode = 200001
+
+	ErrWebhookForumRequiresThreadName ErrorCode = 220001
+	ErrWebhookForumThreadNameAndID    ErrorCode = 220002
+	ErrWebhookOnlyInForum             ErrorCode = 220003
+	ErrWebhookServiceForum            ErrorCode = 220004
+
+	ErrMessageBlockedByHarmfulLinksFilter ErrorCode = 240000
+)
+
+// A validation error for a field of a request body
+type FieldError struct {
+	// Path to the field, such as embeds.0.title, or an empty string for errors about the whole body
+	Path string
+
+	// Validation error code, such as BASE_TYPE_REQUIRED
+	Code string
+
+	// Description of the error
+	Message string
+}
+
+func (f *FieldError) String() string {
+	if f.Path == "" {
+		return fmt.Sprintf("%s: %s", f.Code, f.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", f.Path, f.Code, f.Message)
+}
+
+func (m *ErrorMessage) UnmarshalJSON(data []byte) error {
+	type message ErrorMessage
+	var raw struct {
+		message
+		Code   *ErrorCode      `json:"code"`
+		Errors json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*m = ErrorMessage(raw.message)
+	if raw.Code != nil {
+		m.Code = *raw.Code
+		m.hasCode = true
+	}
+	if len(raw.Errors) > 0 {
+		var errors map[string]json.RawMessage
+		if err := json.Unmarshal(raw.Errors, &errors); err == nil {
+			m.Errors = fieldErrors(nil, errors)
+			sort.SliceStable(m.Errors, func(i, j int) bool {
+				return m.Errors[i].Path < m.Errors[j].Path
+			})
+		}
+	}
+
+	return nil
+}
+
+// Flattens the nested errors object into a list of errors, which are found under _errors keys
+// https://discord.com/developers/docs/reference#error-messages
+func fieldErrors(path []string, errors map[string]json.RawMessage) []*FieldError {
+	var fields []*FieldError
+
+	for key, value := range errors {
+		if key == "_errors" {
+			var list []struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(value, &list); err != nil {
+				continue
+			}
+			for _, e := range list {
+				fields = append(fields, &FieldError{
+					Path:    strings.Join(path, "."),
+					Code:    e.Code,
+					Message: e.Message,
+				})
+			}
+			continue
+		}
+
+		var nested map[string]json.RawMessage
+		if err := json.Unmarshal(value, &nested); err != nil {
+			continue
+		}
+		fields = append(fields, fieldErrors(append(path[:len(path):len(path)], key), nested)...)
+	}
+
+	return fields
+}
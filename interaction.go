@@ -0,0 +1,136 @@
+package eventide
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+var ErrAlreadyResponded = errors.New("interaction has already been responded to")
+
+// Wraps a received interaction with helpers for responding to it
+type Interaction struct {
+	*discord.Interaction
+
+	client *Client
+
+	// Sends the initial interaction response, defaults to the create interaction response endpoint
+	respond func(resp *discord.InteractionResponse) error
+
+	responded     bool
+	respondedLock sync.Mutex
+}
+
+// Creates a responder for an interaction received over the gateway
+func (c *Client) NewInteraction(e *discord.InteractionCreateEvent) *Interaction {
+	i := &Interaction{
+		Interaction: e.Interaction,
+		client:      c,
+	}
+	i.respond = func(resp *discord.InteractionResponse) error {
+		return c.CreateInteractionResponse(i.ID, i.Token, resp)
+	}
+	return i
+}
+
+// Whether an initial response has been sent for the interaction
+func (i *Interaction) Responded() bool {
+	i.respondedLock.Lock()
+	defer i.respondedLock.Unlock()
+	return i.responded
+}
+
+// Sends the initial response to the interaction, which can only be done once
+func (i *Interaction) Respond(resp *discord.InteractionResponse) error {
+	i.respondedLock.Lock()
+	defer i.respondedLock.Unlock()
+
+	if i.responded {
+		return ErrAlreadyResponded
+	}
+	if err := i.respond(resp); err != nil {
+		return err
+	}
+	i.responded = true
+
+	return nil
+}
+
+// Responds to the interaction with a message
+func (i *Interaction) Reply(data *discord.InteractionCallbackData) error {
+	return i.Respond(&discord.InteractionResponse{
+		Type: discord.InteractionCallbackTypeChannelMessageWithSource,
+		Data: data,
+	})
+}
+
+// Acknowledges the interaction, showing a loading state until the response is edited
+func (i *Interaction) Defer(ephemeral bool) error {
+	resp := &discord.InteractionResponse{
+		Type: discord.InteractionCallbackTypeDeferredChannelMessageWithSource,
+	}
+	if ephemeral {
+		resp.Data = &discord.InteractionCallbackData{Flags: discord.MessageFlagsEphemeral}
+	}
+	return i.Respond(resp)
+}
+
+// Acknowledges a component interaction, allowing the message to be edited later
+func (i *Interaction) DeferUpdate() error {
+	return i.Respond(&discord.InteractionResponse{
+		Type: discord.InteractionCallbackTypeDeferredUpdateMessage,
+	})
+}
+
+// Responds to a component interaction by editing the message the component was attached to
+func (i *Interaction) Update(data *discord.InteractionCallbackData) error {
+	return i.Respond(&discord.InteractionResponse{
+		Type: discord.InteractionCallbackTypeUpdateMessage,
+		Data: data,
+	})
+}
+
+// Responds to an autocomplete interaction with up to 25 choices
+func (i *Interaction) Autocomplete(choices []*discord.ApplicationCommandOptionChoice) error {
+	if len(choices) > 25 {
+		choices = choices[:25]
+	}
+	if choices == nil {
+		choices = []*discord.ApplicationCommandOptionChoice{}
+	}
+	return i.Respond(&discord.InteractionResponse{
+		Type: discord.InteractionCallbackTypeApplicationCommandAutocompleteResult,
+		Data: &discord.InteractionCallbackData{Choices: choices},
+	})
+}
+
+// Fetches the initial response message
+func (i *Interaction) GetResponse() (*discord.Message, error) {
+	return i.client.GetOriginalInteractionResponse(i.ApplicationID, i.Token)
+}
+
+// Edits the initial response message, used to fill in deferred responses
+func (i *Interaction) EditResponse(params *discord.EditWebhookMessage) (*discord.Message, error) {
+	return i.client.EditOriginalInteractionResponse(i.ApplicationID, i.Token, params)
+}
+
+// Deletes the initial response message
+func (i *Interaction) DeleteResponse() error {
+	return i.client.DeleteOriginalInteractionResponse(i.ApplicationID, i.Token)
+}
+
+// Sends a followup message to the interaction
+func (i *Interaction) Followup(params *discord.ExecuteWebhook) (*discord.Message, error) {
+	return i.client.CreateFollowupMessage(i.ApplicationID, i.Token, params)
+}
+
+// Edits a followup message
+func (i *Interaction) EditFollowup(messageID string, params *discord.EditWebhookMessage) (*discord.Message, error) {
+	return i.client.EditFollowupMessage(i.ApplicationID, i.Token, messageID, params)
+}
+
+// Deletes a followup message
+func (i *Interaction) DeleteFollowup(messageID string) error {
+	return i.client.DeleteFollowupMessage(i.ApplicationID, i.Token, messageID)
+}
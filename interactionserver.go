@@ -0,0 +1,159 @@
+package eventide
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Receives interactions sent to an application's interactions endpoint URL, verifying their signatures and dispatching
+// them to the client's handlers for *discord.InteractionCreateEvent and *Interaction
+type InteractionServer struct {
+	client    *Client
+	publicKey ed25519.PublicKey
+
+	// How long to wait for a handler to respond before deferring the interaction, defaults to 2.5 seconds. Handlers
+	// that take longer should fill in the deferred response with Interaction.EditResponse
+	ResponseTimeout time.Duration
+}
+
+// Interaction payloads are far smaller than this, larger bodies are rejected
+const maxInteractionBodySize = 1 << 20
+
+// Creates an interaction server for the application with the given hex encoded public key
+func (c *Client) NewInteractionServer(publicKey string) (*InteractionServer, error) {
+	key, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding public key: %s", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key must be %d bytes, got %d", ed25519.PublicKeySize, len(key))
+	}
+
+	return &InteractionServer{
+		client:          c,
+		publicKey:       ed25519.PublicKey(key),
+		ResponseTimeout: 2500 * time.Millisecond,
+	}, nil
+}
+
+// Verifies the X-Signature-Ed25519 and X-Signature-Timestamp headers of an interaction request, returning its body
+// if the signature is valid
+func VerifyInteraction(r *http.Request, key ed25519.PublicKey) ([]byte, bool) {
+	signature, err := hex.DecodeString(r.Header.Get("X-Signature-Ed25519"))
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return nil, false
+	}
+	timestamp := r.Header.Get("X-Signature-Timestamp")
+	if timestamp == "" {
+		return nil, false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxInteractionBodySize))
+	if err != nil {
+		return nil, false
+	}
+
+	var msg bytes.Buffer
+	msg.WriteString(timestamp)
+	msg.Write(body)
+
+	return body, ed25519.Verify(key, msg.Bytes(), signature)
+}
+
+func (s *InteractionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, ok := VerifyInteraction(r, s.publicKey)
+	if !ok {
+		http.Error(w, "invalid request signature", http.StatusUnauthorized)
+		return
+	}
+
+	var interaction discord.Interaction
+	if err := json.Unmarshal(body, &interaction); err != nil {
+		http.Error(w, "invalid interaction", http.StatusBadRequest)
+		return
+	}
+
+	if interaction.Type == discord.InteractionTypePing {
+		writeInteractionResponse(w, &discord.InteractionResponse{Type: discord.InteractionCallbackTypePong})
+		return
+	}
+
+	// the initial response is sent as the HTTP response, Interaction.Respond ensures this is only called once
+	responses := make(chan *discord.InteractionResponse, 1)
+	i := &Interaction{
+		Interaction: &interaction,
+		client:      s.client,
+		respond: func(resp *discord.InteractionResponse) error {
+			responses <- resp
+			return nil
+		},
+	}
+
+	// handlers run in the background so they can keep working after a deferred response is sent
+	go func() {
+		s.client.dispatch(&discord.InteractionCreateEvent{Interaction: &interaction})
+		s.client.dispatch(i)
+	}()
+
+	timeout := s.ResponseTimeout
+	if timeout <= 0 {
+		timeout = 2500 * time.Millisecond
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case resp := <-responses:
+		writeInteractionResponse(w, resp)
+	case <-timer.C:
+		// a handler may respond at the same time, in which case its response is sent instead
+		if err := i.Respond(deferredResponse(&interaction)); err == nil {
+			if interaction.Type == discord.InteractionTypeApplicationCommandAutocomplete {
+				s.client.log(LogWarn, "sent no choices for autocomplete interaction %s after no response within %s", interaction.ID, timeout)
+			} else {
+				s.client.log(LogWarn, "deferred interaction %s after no response within %s", interaction.ID, timeout)
+			}
+		}
+		writeInteractionResponse(w, <-responses)
+	case <-r.Context().Done():
+	}
+}
+
+// Acknowledges an interaction so that it can be responded to later. Autocomplete interactions can't be deferred, so
+// they're sent an empty list of choices
+func deferredResponse(i *discord.Interaction) *discord.InteractionResponse {
+	switch i.Type {
+	case discord.InteractionTypeApplicationCommandAutocomplete:
+		return &discord.InteractionResponse{
+			Type: discord.InteractionCallbackTypeApplicationCommandAutocompleteResult,
+			Data: &discord.InteractionCallbackData{Choices: []*discord.ApplicationCommandOptionChoice{}},
+		}
+	case discord.InteractionTypeMessageComponent:
+		return &discord.InteractionResponse{Type: discord.InteractionCallbackTypeDeferredUpdateMessage}
+	}
+	return &discord.InteractionResponse{Type: discord.InteractionCallbackTypeDeferredChannelMessageWithSource}
+}
+
+func writeInteractionResponse(w http.ResponseWriter, resp *discord.InteractionResponse) {
+	body, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, "error encoding response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
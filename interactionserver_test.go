@@ -0,0 +1,193 @@
+package eventide
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Builds an interaction request signed with the given key
+func signedInteractionRequest(key ed25519.PrivateKey, body string) *http.Request {
+	timestamp := "1700000000"
+	signature := ed25519.Sign(key, []byte(timestamp+body))
+
+	r := httptest.NewRequest(http.MethodPost, "/interactions", strings.NewReader(body))
+	r.Header.Set("X-Signature-Ed25519", hex.EncodeToString(signature))
+	r.Header.Set("X-Signature-Timestamp", timestamp)
+	return r
+}
+
+func TestVerifyInteraction(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherPrivate, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := `{"id":"1","type":1}`
+
+	tests := []struct {
+		name    string
+		request func() *http.Request
+		valid   bool
+	}{
+		{
+			name:    "valid",
+			request: func() *http.Request { return signedInteractionRequest(private, body) },
+			valid:   true,
+		},
+		{
+			name:    "signed by another key",
+			request: func() *http.Request { return signedInteractionRequest(otherPrivate, body) },
+		},
+		{
+			name: "tampered body",
+			request: func() *http.Request {
+				r := signedInteractionRequest(private, body)
+				r.Body = io.NopCloser(strings.NewReader(`{"id":"2","type":1}`))
+				return r
+			},
+		},
+		{
+			name: "tampered timestamp",
+			request: func() *http.Request {
+				r := signedInteractionRequest(private, body)
+				r.Header.Set("X-Signature-Timestamp", "1700000001")
+				return r
+			},
+		},
+		{
+			name: "malformed signature",
+			request: func() *http.Request {
+				r := signedInteractionRequest(private, body)
+				r.Header.Set("X-Signature-Ed25519", "not hex")
+				return r
+			},
+		},
+		{
+			name: "missing signature",
+			request: func() *http.Request {
+				r := signedInteractionRequest(private, body)
+				r.Header.Del("X-Signature-Ed25519")
+				return r
+			},
+		},
+		{
+			name: "missing timestamp",
+			request: func() *http.Request {
+				r := signedInteractionRequest(private, body)
+				r.Header.Del("X-Signature-Timestamp")
+				return r
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := VerifyInteraction(test.request(), public)
+			if ok != test.valid {
+				t.Fatalf("expected valid %t, got %t", test.valid, ok)
+			}
+			if ok && string(got) != body {
+				t.Fatalf("expected body %s, got %s", body, got)
+			}
+		})
+	}
+}
+
+func newTestInteractionServer(t *testing.T) (*InteractionServer, ed25519.PrivateKey) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewClient(ClientConfig{Token: "token"}).NewInteractionServer(hex.EncodeToString(public))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, private
+}
+
+func serveInteraction(s *InteractionServer, r *http.Request) (int, *discord.InteractionResponse) {
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+
+	var resp discord.InteractionResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		return w.Code, nil
+	}
+	return w.Code, &resp
+}
+
+func TestInteractionServerSignature(t *testing.T) {
+	s, private := newTestInteractionServer(t)
+	_, otherPrivate, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ping := `{"id":"1","type":1}`
+
+	code, resp := serveInteraction(s, signedInteractionRequest(private, ping))
+	if code != http.StatusOK || resp == nil || resp.Type != discord.InteractionCallbackTypePong {
+		t.Fatalf("expected pong for a valid ping, got status %d and %+v", code, resp)
+	}
+
+	if code, _ := serveInteraction(s, signedInteractionRequest(otherPrivate, ping)); code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401 for an invalid signature, got %d", code)
+	}
+
+	r := signedInteractionRequest(private, ping)
+	r.Header.Del("X-Signature-Ed25519")
+	r.Header.Del("X-Signature-Timestamp")
+	if code, _ := serveInteraction(s, r); code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401 for missing signature headers, got %d", code)
+	}
+}
+
+func TestInteractionServerTimeout(t *testing.T) {
+	s, private := newTestInteractionServer(t)
+	s.ResponseTimeout = 10 * time.Millisecond
+
+	tests := []struct {
+		name     string
+		body     string
+		response discord.InteractionCallbackType
+	}{
+		{
+			name:     "command",
+			body:     `{"id":"1","type":2}`,
+			response: discord.InteractionCallbackTypeDeferredChannelMessageWithSource,
+		},
+		{
+			name:     "component",
+			body:     `{"id":"1","type":3}`,
+			response: discord.InteractionCallbackTypeDeferredUpdateMessage,
+		},
+		{
+			name:     "autocomplete",
+			body:     `{"id":"1","type":4}`,
+			response: discord.InteractionCallbackTypeApplicationCommandAutocompleteResult,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code, resp := serveInteraction(s, signedInteractionRequest(private, test.body))
+			if code != http.StatusOK || resp == nil || resp.Type != test.response {
+				t.Fatalf("expected response type %d, got status %d and %+v", test.response, code, resp)
+			}
+			if test.response == discord.InteractionCallbackTypeApplicationCommandAutocompleteResult && (resp.Data == nil || resp.Data.Choices == nil) {
+				t.Fatal("expected an empty choices array in the autocomplete result")
+			}
+		})
+	}
+}
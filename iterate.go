@@ -0,0 +1,367 @@
+package eventide
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Requests a page of up to size items, returning whether there may be more pages after it
+type pageFunc[T any] func(ctx context.Context, size int) (page []T, more bool, err error)
+
+// Iterates over the items of a paginated endpoint, requesting pages as they're needed
+//
+//	it := c.IterateMessages(ctx, channelID, nil)
+//	for it.Next() {
+//		message := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	ctx      context.Context
+	fetch    pageFunc[T]
+	pageSize int
+	limit    int
+	while    func(T) bool
+
+	page    []T
+	more    bool
+	count   int
+	current T
+	err     error
+	done    bool
+}
+
+func newIterator[T any](ctx context.Context, pageSize int, limit int, while func(T) bool, fetch pageFunc[T]) *Iterator[T] {
+	return &Iterator[T]{
+		ctx:      ctx,
+		fetch:    fetch,
+		pageSize: pageSize,
+		limit:    limit,
+		while:    while,
+		more:     true,
+	}
+}
+
+// Advances to the next item, returning false once there are no more items, the limit or predicate has stopped the
+// iterator, or an error occurred
+func (it *Iterator[T]) Next() bool {
+	if it.done {
+		return false
+	}
+	if it.limit > 0 && it.count >= it.limit {
+		return it.stop(nil)
+	}
+
+	if len(it.page) == 0 {
+		if !it.more {
+			return it.stop(nil)
+		}
+
+		size := it.pageSize
+		if it.limit > 0 && it.limit-it.count < size {
+			size = it.limit - it.count
+		}
+
+		page, more, err := it.fetchPage(size)
+		if err != nil {
+			return it.stop(err)
+		}
+		it.page, it.more = page, more && len(page) > 0
+		if len(it.page) == 0 {
+			return it.stop(nil)
+		}
+	}
+
+	it.current = it.page[0]
+	it.page = it.page[1:]
+	if it.while != nil && !it.while(it.current) {
+		return it.stop(nil)
+	}
+	it.count++
+
+	return true
+}
+
+// Requests a page, waiting and trying again if the request was rate limited
+func (it *Iterator[T]) fetchPage(size int) ([]T, bool, error) {
+	for {
+		if err := it.ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		page, more, err := it.fetch(it.ctx, size)
+
+		var httpErr HTTPError
+		if !errors.As(err, &httpErr) || httpErr.Response.StatusCode != http.StatusTooManyRequests {
+			return page, more, err
+		}
+
+		var rateLimit discord.RateLimitResponse
+		if err := json.Unmarshal(httpErr.ResponseBody, &rateLimit); err != nil {
+			return nil, false, err
+		}
+
+		select {
+		case <-time.After(time.Duration(rateLimit.RetryAfter * float64(time.Second))):
+		case <-it.ctx.Done():
+			return nil, false, it.ctx.Err()
+		}
+	}
+}
+
+func (it *Iterator[T]) stop(err error) bool {
+	var zero T
+	it.current = zero
+	it.page = nil
+	it.err = err
+	it.done = true
+	return false
+}
+
+// Returns the current item
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Returns the error that stopped the iterator, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Collects the remaining items
+func (it *Iterator[T]) All() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
+
+// Options for iterating over a channel's messages
+type IterateMessagesOptions struct {
+	// Start from messages before this message ID, defaults to the latest message
+	Before string
+
+	// Iterate from oldest to newest, starting from messages after this message ID
+	After string
+
+	// Maximum number of messages to return, or 0 for no limit
+	Limit int
+
+	// Messages are returned until this returns false
+	While func(*discord.Message) bool
+}
+
+// Iterates over a channel's messages, from newest to oldest unless After is set
+// https://discord.com/developers/docs/resources/channel#get-channel-messages
+func (c *Client) IterateMessages(ctx context.Context, channelID string, opts *IterateMessagesOptions) *Iterator[*discord.Message] {
+	if opts == nil {
+		opts = &IterateMessagesOptions{}
+	}
+	before, after := opts.Before, opts.After
+
+	return newIterator(ctx, 100, opts.Limit, opts.While, func(ctx context.Context, size int) ([]*discord.Message, bool, error) {
+		params := &discord.GetChannelMessages{Limit: size}
+		if after != "" {
+			params.After = after
+		} else {
+			params.Before = before
+		}
+
+		messages, err := c.GetChannelMessages(channelID, params, WithContext(ctx))
+		if err != nil || len(messages) == 0 {
+			return nil, false, err
+		}
+
+		// pages are always newest first
+		if after != "" {
+			sort.Slice(messages, func(i, j int) bool {
+				return snowflakeLess(messages[i].ID, messages[j].ID)
+			})
+			after = messages[len(messages)-1].ID
+		} else {
+			before = messages[len(messages)-1].ID
+		}
+
+		return messages, len(messages) == size, nil
+	})
+}
+
+// Options for iterating over the users who reacted to a message
+type IterateReactionsOptions struct {
+	// Start from users after this user ID
+	After string
+
+	// Maximum number of users to return, or 0 for no limit
+	Limit int
+
+	// Users are returned until this returns false
+	While func(*discord.User) bool
+}
+
+// Iterates over the users who reacted to a message with an emoji, in order of user ID
+// https://discord.com/developers/docs/resources/channel#get-reactions
+func (c *Client) IterateReactions(ctx context.Context, channelID string, messageID string, emoji string, opts *IterateReactionsOptions) *Iterator[*discord.User] {
+	if opts == nil {
+		opts = &IterateReactionsOptions{}
+	}
+	after := opts.After
+
+	return newIterator(ctx, 100, opts.Limit, opts.While, func(ctx context.Context, size int) ([]*discord.User, bool, error) {
+		users, err := c.GetReactions(channelID, messageID, emoji, &discord.GetReactions{After: after, Limit: size}, WithContext(ctx))
+		if err != nil || len(users) == 0 {
+			return nil, false, err
+		}
+		after = users[len(users)-1].ID
+
+		return users, len(users) == size, nil
+	})
+}
+
+// Options for iterating over archived threads
+type IterateThreadsOptions struct {
+	// Start from threads archived before this time, defaults to now
+	Before time.Time
+
+	// Maximum number of threads to return, or 0 for no limit
+	Limit int
+
+	// Threads are returned until this returns false
+	While func(*discord.Channel) bool
+}
+
+// Iterates over a channel's public archived threads, from most to least recently archived
+// https://discord.com/developers/docs/resources/channel#list-public-archived-threads
+func (c *Client) IteratePublicArchivedThreads(ctx context.Context, channelID string, opts *IterateThreadsOptions) *Iterator[*discord.Channel] {
+	return c.iterateArchivedThreads(ctx, opts, func(params *discord.ListArchivedThreads, opt RequestOption) (*discord.ArchivedThreads, error) {
+		return c.ListPublicArchivedThreads(channelID, params, opt)
+	})
+}
+
+// Iterates over a channel's private archived threads, from most to least recently archived
+// https://discord.com/developers/docs/resources/channel#list-private-archived-threads
+func (c *Client) IteratePrivateArchivedThreads(ctx context.Context, channelID string, opts *IterateThreadsOptions) *Iterator[*discord.Channel] {
+	return c.iterateArchivedThreads(ctx, opts, func(params *discord.ListArchivedThreads, opt RequestOption) (*discord.ArchivedThreads, error) {
+		return c.ListPrivateArchivedThreads(channelID, params, opt)
+	})
+}
+
+func (c *Client) iterateArchivedThreads(ctx context.Context, opts *IterateThreadsOptions, list func(*discord.ListArchivedThreads, RequestOption) (*discord.ArchivedThreads, error)) *Iterator[*discord.Channel] {
+	if opts == nil {
+		opts = &IterateThreadsOptions{}
+	}
+	before := opts.Before
+
+	return newIterator(ctx, 100, opts.Limit, opts.While, func(ctx context.Context, size int) ([]*discord.Channel, bool, error) {
+		params := &discord.ListArchivedThreads{Limit: size}
+		if !before.IsZero() {
+			params.Before = &discord.Timestamp{Time: before}
+		}
+
+		threads, err := list(params, WithContext(ctx))
+		if err != nil || threads == nil || len(threads.Threads) == 0 {
+			return nil, false, err
+		}
+
+		last := threads.Threads[len(threads.Threads)-1]
+		if last.ThreadMetadata == nil {
+			return threads.Threads, false, nil
+		}
+		before = last.ThreadMetadata.ArchiveTimestamp.Time
+
+		return threads.Threads, threads.HasMore, nil
+	})
+}
+
+// Options for iterating over a guild's bans
+type IterateBansOptions struct {
+	// Start from bans of users after this user ID
+	After string
+
+	// Maximum number of bans to return, or 0 for no limit
+	Limit int
+
+	// Bans are returned until this returns false
+	While func(*discord.Ban) bool
+}
+
+// Iterates over a guild's bans, in order of user ID
+// https://discord.com/developers/docs/resources/guild#get-guild-bans
+func (c *Client) IterateGuildBans(ctx context.Context, guildID string, opts *IterateBansOptions) *Iterator[*discord.Ban] {
+	if opts == nil {
+		opts = &IterateBansOptions{}
+	}
+	after := opts.After
+
+	return newIterator(ctx, 1000, opts.Limit, opts.While, func(ctx context.Context, size int) ([]*discord.Ban, bool, error) {
+		bans, err := c.GetGuildBans(guildID, &discord.GetGuildBans{After: after, Limit: size}, WithContext(ctx))
+		if err != nil || len(bans) == 0 {
+			return nil, false, err
+		}
+		if last := bans[len(bans)-1]; last.User != nil {
+			after = last.User.ID
+		} else {
+			return bans, false, nil
+		}
+
+		return bans, len(bans) == size, nil
+	})
+}
+
+// Options for iterating over a guild's audit log
+type IterateAuditLogOptions struct {
+	// Only return entries made by this user ID
+	UserID string
+
+	// Only return entries for this type of action
+	ActionType discord.AuditLogEvent
+
+	// Start from entries before this entry ID, defaults to the latest entry
+	Before string
+
+	// Maximum number of entries to return, or 0 for no limit
+	Limit int
+
+	// Entries are returned until this returns false
+	While func(*discord.AuditLogEntry) bool
+}
+
+// Iterates over a guild's audit log entries, from newest to oldest
+// https://discord.com/developers/docs/resources/audit-log#get-guild-audit-log
+func (c *Client) IterateAuditLog(ctx context.Context, guildID string, opts *IterateAuditLogOptions) *Iterator[*discord.AuditLogEntry] {
+	if opts == nil {
+		opts = &IterateAuditLogOptions{}
+	}
+	before := opts.Before
+
+	return newIterator(ctx, 100, opts.Limit, opts.While, func(ctx context.Context, size int) ([]*discord.AuditLogEntry, bool, error) {
+		auditLog, err := c.GetGuildAuditLog(guildID, &discord.GetGuildAuditLog{
+			UserID:     opts.UserID,
+			ActionType: opts.ActionType,
+			Before:     before,
+			Limit:      size,
+		}, WithContext(ctx))
+		if err != nil || len(auditLog.AuditLogEntries) == 0 {
+			return nil, false, err
+		}
+		entries := auditLog.AuditLogEntries
+		before = entries[len(entries)-1].ID
+
+		return entries, len(entries) == size, nil
+	})
+}
+
+// Compares two snowflake IDs numerically
+func snowflakeLess(a string, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
@@ -0,0 +1,202 @@
+package eventide
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Serves a channel's messages with IDs 1 to count the way Discord pages them, always newest first
+type fakeMessageServer struct {
+	count int
+
+	lock        sync.Mutex
+	queries     []string
+	rateLimited int
+}
+
+func (s *fakeMessageServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	s.queries = append(s.queries, r.URL.RawQuery)
+	limited := s.rateLimited > 0
+	if limited {
+		s.rateLimited--
+	}
+	s.lock.Unlock()
+
+	if limited {
+		w.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(w).Encode(discord.RateLimitResponse{Message: "You are being rate limited.", RetryAfter: 0.01})
+		return
+	}
+
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	before, _ := strconv.Atoi(q.Get("before"))
+	after, _ := strconv.Atoi(q.Get("after"))
+
+	var ids []int
+	if q.Has("after") {
+		// the oldest messages after the cursor
+		for id := after + 1; id <= s.count && len(ids) < limit; id++ {
+			ids = append([]int{id}, ids...)
+		}
+	} else {
+		if !q.Has("before") {
+			before = s.count + 1
+		}
+		for id := before - 1; id >= 1 && len(ids) < limit; id-- {
+			ids = append(ids, id)
+		}
+	}
+
+	messages := make([]*discord.Message, len(ids))
+	for i, id := range ids {
+		messages[i] = &discord.Message{ID: strconv.Itoa(id)}
+	}
+	json.NewEncoder(w).Encode(messages)
+}
+
+func (s *fakeMessageServer) Queries() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]string(nil), s.queries...)
+}
+
+func newTestMessageServer(t *testing.T, count int) (*Client, *fakeMessageServer) {
+	s := &fakeMessageServer{count: count}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	return NewClient(ClientConfig{Token: "token", APIURL: srv.URL}), s
+}
+
+func messageIDs(messages []*discord.Message) []int {
+	ids := make([]int, len(messages))
+	for i, m := range messages {
+		ids[i], _ = strconv.Atoi(m.ID)
+	}
+	return ids
+}
+
+func idRange(from int, to int) []int {
+	var ids []int
+	step := 1
+	if to < from {
+		step = -1
+	}
+	for id := from; id != to+step; id += step {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestIterateMessages(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *IterateMessagesOptions
+		ids     []int
+		queries []string
+	}{
+		{
+			name:    "before",
+			opts:    nil,
+			ids:     idRange(250, 1),
+			queries: []string{"limit=100", "before=151&limit=100", "before=51&limit=100"},
+		},
+		{
+			name:    "before cursor",
+			opts:    &IterateMessagesOptions{Before: "120"},
+			ids:     idRange(119, 1),
+			queries: []string{"before=120&limit=100", "before=20&limit=100"},
+		},
+		{
+			name:    "after sorted ascending",
+			opts:    &IterateMessagesOptions{After: "100"},
+			ids:     idRange(101, 250),
+			queries: []string{"after=100&limit=100", "after=200&limit=100"},
+		},
+		{
+			name:    "limit trims the last page",
+			opts:    &IterateMessagesOptions{Limit: 130},
+			ids:     idRange(250, 121),
+			queries: []string{"limit=100", "before=151&limit=30"},
+		},
+		{
+			name: "while",
+			opts: &IterateMessagesOptions{While: func(m *discord.Message) bool {
+				return m.ID != "240"
+			}},
+			ids:     idRange(250, 241),
+			queries: []string{"limit=100"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, s := newTestMessageServer(t, 250)
+
+			messages, err := c.IterateMessages(context.Background(), "channel", test.opts).All()
+			if err != nil {
+				t.Fatalf("error iterating messages: %s", err)
+			}
+			if ids := messageIDs(messages); !reflect.DeepEqual(ids, test.ids) {
+				t.Fatalf("expected messages %v, got %v", test.ids, ids)
+			}
+			if queries := s.Queries(); !reflect.DeepEqual(queries, test.queries) {
+				t.Fatalf("expected queries %v, got %v", test.queries, queries)
+			}
+		})
+	}
+}
+
+func TestIteratorRateLimit(t *testing.T) {
+	c, s := newTestMessageServer(t, 50)
+	s.rateLimited = 2
+
+	messages, err := c.IterateMessages(context.Background(), "channel", nil).All()
+	if err != nil {
+		t.Fatalf("error iterating messages: %s", err)
+	}
+	if ids := messageIDs(messages); !reflect.DeepEqual(ids, idRange(50, 1)) {
+		t.Fatalf("expected all messages after the rate limit, got %v", ids)
+	}
+	if queries := s.Queries(); len(queries) != 3 {
+		t.Fatalf("expected 2 rate limited requests and 1 retry, got %d requests", len(queries))
+	}
+}
+
+func TestIteratorContextCancelled(t *testing.T) {
+	// the retry_after is far longer than the context's timeout
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"message":"You are being rate limited.","retry_after":60,"global":false}`)
+	}))
+	defer srv.Close()
+	c := NewClient(ClientConfig{Token: "token", APIURL: srv.URL})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	it := c.IterateMessages(ctx, "channel", nil)
+	if it.Next() {
+		t.Fatal("expected no messages")
+	}
+	if !errors.Is(it.Err(), context.DeadlineExceeded) {
+		t.Fatalf("expected the context's error, got %v", it.Err())
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the wait to stop with the context, took %s", elapsed)
+	}
+}
@@ -0,0 +1,256 @@
+package eventide
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Members and presences returned by a Request Guild Members payload
+type GuildMembersResult struct {
+	// The guild members
+	Members []*discord.GuildMember
+
+	// Presences of the members, if they were requested
+	Presences []*discord.PresenceUpdateEvent
+
+	// Requested user IDs that aren't in the guild
+	NotFound []string
+}
+
+// How long chunking a guild on startup can take before it's given up on
+const guildChunkTimeout = 2 * time.Minute
+
+type memberRequest struct {
+	result   *GuildMembersResult
+	received map[int64]bool
+	done     chan struct{}
+}
+
+// Requests members of a guild over the gateway, returning once every chunk of the response has been received. Fills
+// the member cache if ClientConfig.CacheMembers is enabled
+// https://discord.com/developers/docs/topics/gateway#request-guild-members
+func (c *Client) RequestGuildMembers(ctx context.Context, params discord.GuildRequestMembers) (*GuildMembersResult, error) {
+	if params.Query == "" && len(params.UserIDs) == 0 && c.intents&discord.IntentGuildMembers == 0 {
+		return nil, errors.New("requesting all guild members requires the guild members intent")
+	}
+	if params.Presences && c.intents&discord.IntentGuildPresences == 0 {
+		return nil, errors.New("requesting presences requires the guild presences intent")
+	}
+
+	nonce, err := memberRequestNonce()
+	if err != nil {
+		return nil, fmt.Errorf("error generating nonce: %s", err)
+	}
+	params.Nonce = nonce
+
+	req := &memberRequest{
+		result:   &GuildMembersResult{},
+		received: make(map[int64]bool),
+		done:     make(chan struct{}),
+	}
+
+	c.memberRequestsLock.Lock()
+	c.memberRequests[nonce] = req
+	c.memberRequestsLock.Unlock()
+
+	defer func() {
+		c.memberRequestsLock.Lock()
+		delete(c.memberRequests, nonce)
+		c.memberRequestsLock.Unlock()
+	}()
+
+	if err := c.sendPayload(&discord.GatewayPayload[discord.GuildRequestMembers]{Op: 8, Data: params}); err != nil {
+		return nil, fmt.Errorf("error sending request guild members payload: %s", err)
+	}
+
+	select {
+	case <-req.done:
+		return req.result, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Returns a cached guild member, members are only cached if ClientConfig.CacheMembers is enabled
+func (c *Client) GuildMember(guildID string, userID string) (*discord.GuildMember, bool) {
+	c.membersLock.RLock()
+	defer c.membersLock.RUnlock()
+
+	m, ok := c.members[guildID][userID]
+	return m, ok
+}
+
+// Returns the cached members of a guild, members are only cached if ClientConfig.CacheMembers is enabled
+func (c *Client) GuildMembers(guildID string) []*discord.GuildMember {
+	c.membersLock.RLock()
+	defer c.membersLock.RUnlock()
+
+	members := make([]*discord.GuildMember, 0, len(c.members[guildID]))
+	for _, m := range c.members[guildID] {
+		members = append(members, m)
+	}
+	return members
+}
+
+// Adds members to a guild's cached members
+func (c *Client) cacheMembers(guildID string, members ...*discord.GuildMember) {
+	if !c.memberCache {
+		return
+	}
+
+	c.membersLock.Lock()
+	defer c.membersLock.Unlock()
+
+	guild, ok := c.members[guildID]
+	if !ok {
+		guild = make(map[string]*discord.GuildMember)
+		c.members[guildID] = guild
+	}
+	for _, m := range members {
+		if m.User != nil {
+			guild[m.User.ID] = m
+		}
+	}
+}
+
+func (c *Client) handleMembersChunk(e *discord.GuildMembersChunkEvent) {
+	c.cacheMembers(e.GuildID, e.Members...)
+
+	if e.Nonce == "" {
+		return
+	}
+
+	c.memberRequestsLock.Lock()
+	defer c.memberRequestsLock.Unlock()
+
+	req, ok := c.memberRequests[e.Nonce]
+	if !ok || req.received[e.ChunkIndex] {
+		return
+	}
+
+	// chunks are handled concurrently, so they can arrive out of order
+	req.received[e.ChunkIndex] = true
+	req.result.Members = append(req.result.Members, e.Members...)
+	req.result.Presences = append(req.result.Presences, e.Presences...)
+	req.result.NotFound = append(req.result.NotFound, e.NotFound...)
+
+	if int64(len(req.received)) >= e.ChunkCount {
+		delete(c.memberRequests, e.Nonce)
+		close(req.done)
+	}
+}
+
+func (c *Client) handleMemberGuildCreate(g *discord.GuildCreateEvent) {
+	if g.Unavailable || g.Guild == nil {
+		return
+	}
+	c.cacheMembers(g.ID, g.Members...)
+
+	if c.chunkGuilds && g.Large {
+		go c.chunkGuild(g.ID)
+	}
+}
+
+// Requests all members of a guild into the member cache, one guild at a time so that startup doesn't fill the gateway
+// send queue
+func (c *Client) chunkGuild(guildID string) {
+	c.chunkLock.Lock()
+	defer c.chunkLock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), guildChunkTimeout)
+	defer cancel()
+
+	res, err := c.RequestGuildMembers(ctx, discord.GuildRequestMembers{GuildID: guildID})
+	if err != nil {
+		c.log(LogWarn, "error chunking guild %s: %s", guildID, err)
+		return
+	}
+	c.log(LogInfo, "chunked %d members of guild %s", len(res.Members), guildID)
+}
+
+func (c *Client) handleMemberAdd(m *discord.GuildMemberAddEvent) {
+	if m.GuildMember != nil {
+		c.cacheMembers(m.GuildID, m.GuildMember)
+	}
+}
+
+// Merges a partial member update into the cached member, keeping the fields that weren't sent
+func (c *Client) handleMemberUpdate(m *discord.GuildMemberUpdateEvent) {
+	if !c.memberCache || m.User == nil {
+		return
+	}
+
+	c.membersLock.Lock()
+	defer c.membersLock.Unlock()
+
+	guild, ok := c.members[m.GuildID]
+	if !ok {
+		guild = make(map[string]*discord.GuildMember)
+		c.members[m.GuildID] = guild
+	}
+
+	// cached members are shared with callers, so the update is applied to a copy
+	member := &discord.GuildMember{}
+	if cached, ok := guild[m.User.ID]; ok {
+		*member = *cached
+	}
+	mergeMemberUpdate(member, m)
+	guild[m.User.ID] = member
+}
+
+func mergeMemberUpdate(member *discord.GuildMember, m *discord.GuildMemberUpdateEvent) {
+	member.User = m.User
+	member.Roles = m.Roles
+	member.Avatar = m.Avatar
+	if !m.JoinedAt.IsZero() {
+		member.JoinedAt = m.JoinedAt
+	}
+	if m.Nick != nil {
+		member.Nick = *m.Nick
+	}
+	if m.PremiumSince != nil {
+		member.PremiumSince = *m.PremiumSince
+	}
+	if m.Deaf != nil {
+		member.Deaf = *m.Deaf
+	}
+	if m.Mute != nil {
+		member.Mute = *m.Mute
+	}
+	if m.Pending != nil {
+		member.Pending = *m.Pending
+	}
+	if m.CommunicationDisabledUntil != nil {
+		member.CommunicationDisabledUntil = *m.CommunicationDisabledUntil
+	}
+}
+
+func (c *Client) handleMemberRemove(m *discord.GuildMemberRemoveEvent) {
+	if m.User == nil {
+		return
+	}
+
+	c.membersLock.Lock()
+	delete(c.members[m.GuildID], m.User.ID)
+	c.membersLock.Unlock()
+}
+
+func (c *Client) handleMemberGuildDelete(g *discord.GuildDeleteEvent) {
+	c.membersLock.Lock()
+	delete(c.members, g.ID)
+	c.membersLock.Unlock()
+}
+
+func memberRequestNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
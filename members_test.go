@@ -0,0 +1,71 @@
+package eventide
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+func TestHandleMemberUpdate(t *testing.T) {
+	c := NewClient(ClientConfig{Token: "token", CacheMembers: true})
+
+	joined := discord.Timestamp{Time: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	timeout := discord.Timestamp{Time: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c.handleMemberAdd(&discord.GuildMemberAddEvent{
+		GuildID: "guild",
+		GuildMember: &discord.GuildMember{
+			User:                       &discord.User{ID: "user"},
+			Nick:                       "nick",
+			Roles:                      []string{"1"},
+			JoinedAt:                   joined,
+			Deaf:                       true,
+			Mute:                       true,
+			Permissions:                "8",
+			CommunicationDisabledUntil: timeout,
+		},
+	})
+	original, _ := c.GuildMember("guild", "user")
+
+	update := func(data string) *discord.GuildMember {
+		t.Helper()
+		var e discord.GuildMemberUpdateEvent
+		if err := json.Unmarshal([]byte(data), &e); err != nil {
+			t.Fatalf("error decoding update: %s", err)
+		}
+		c.handleMemberUpdate(&e)
+		m, ok := c.GuildMember("guild", "user")
+		if !ok {
+			t.Fatal("member wasn't cached")
+		}
+		return m
+	}
+
+	// fields that aren't sent are kept
+	m := update(`{"guild_id":"guild","user":{"id":"user"},"roles":["1","2"],"avatar":null,"joined_at":null,"mute":false}`)
+	expected := discord.GuildMember{
+		User:                       &discord.User{ID: "user"},
+		Nick:                       "nick",
+		Roles:                      []string{"1", "2"},
+		JoinedAt:                   joined,
+		Deaf:                       true,
+		Permissions:                "8",
+		CommunicationDisabledUntil: timeout,
+	}
+	if !reflect.DeepEqual(*m, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, *m)
+	}
+
+	// null removes the nickname and timeout
+	m = update(`{"guild_id":"guild","user":{"id":"user"},"roles":[],"nick":null,"communication_disabled_until":null}`)
+	if m.Nick != "" || !m.CommunicationDisabledUntil.IsZero() || !m.Deaf || !m.JoinedAt.Equal(joined.Time) {
+		t.Fatalf("unexpected member after removing the nickname and timeout: %+v", *m)
+	}
+
+	// the member returned before the updates isn't modified
+	if original.Nick != "nick" || !original.Mute || len(original.Roles) != 1 {
+		t.Fatalf("the previously returned member was modified: %+v", *original)
+	}
+}
@@ -0,0 +1,189 @@
+package eventide
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Builds a modal to be shown in response to an interaction, each text input is placed in its own action row
+type ModalBuilder struct {
+	customID string
+	title    string
+	inputs   []*discord.TextInput
+}
+
+// Creates a modal builder with the custom ID its submit will be routed by and the title shown to the user
+func NewModal(customID string, title string) *ModalBuilder {
+	return &ModalBuilder{
+		customID: customID,
+		title:    title,
+	}
+}
+
+// Adds a text input to the modal
+func (m *ModalBuilder) TextInput(input *discord.TextInput) *ModalBuilder {
+	m.inputs = append(m.inputs, input)
+	return m
+}
+
+// Adds a single-line text input to the modal, with the length limits ignored if zero
+func (m *ModalBuilder) ShortInput(customID string, label string, required bool, minLength int, maxLength int) *ModalBuilder {
+	return m.TextInput(&discord.TextInput{
+		CustomID:  customID,
+		Style:     discord.TextInputStyleShort,
+		Label:     label,
+		Required:  &required,
+		MinLength: minLength,
+		MaxLength: maxLength,
+	})
+}
+
+// Adds a multi-line text input to the modal, with the length limits ignored if zero
+func (m *ModalBuilder) ParagraphInput(customID string, label string, required bool, minLength int, maxLength int) *ModalBuilder {
+	return m.TextInput(&discord.TextInput{
+		CustomID:  customID,
+		Style:     discord.TextInputStyleParagraph,
+		Label:     label,
+		Required:  &required,
+		MinLength: minLength,
+		MaxLength: maxLength,
+	})
+}
+
+// Validates the modal against Discord's limits and builds its response data
+func (m *ModalBuilder) Build() (*discord.InteractionCallbackData, error) {
+	if m.customID == "" || utf8.RuneCountInString(m.customID) > 100 {
+		return nil, errors.New("modal custom ID must be between 1 and 100 characters")
+	}
+	if m.title == "" || utf8.RuneCountInString(m.title) > 45 {
+		return nil, errors.New("modal title must be between 1 and 45 characters")
+	}
+	if len(m.inputs) == 0 || len(m.inputs) > 5 {
+		return nil, errors.New("modal must have between 1 and 5 text inputs")
+	}
+
+	data := &discord.InteractionCallbackData{
+		CustomID: m.customID,
+		Title:    m.title,
+	}
+
+	seen := make(map[string]bool, len(m.inputs))
+	for _, input := range m.inputs {
+		if err := validateTextInput(input); err != nil {
+			return nil, fmt.Errorf("invalid text input %s: %s", input.CustomID, err)
+		}
+		if seen[input.CustomID] {
+			return nil, fmt.Errorf("duplicate text input %s", input.CustomID)
+		}
+		seen[input.CustomID] = true
+
+		input.Type = discord.ComponentTypeTextInput
+		if input.Style == 0 {
+			input.Style = discord.TextInputStyleShort
+		}
+		data.Components = append(data.Components, &discord.ActionRow{
+			Type:       discord.ComponentTypeActionRow,
+			Components: discord.Components{input},
+		})
+	}
+
+	return data, nil
+}
+
+func validateTextInput(input *discord.TextInput) error {
+	switch {
+	case input.CustomID == "" || utf8.RuneCountInString(input.CustomID) > 100:
+		return errors.New("custom ID must be between 1 and 100 characters")
+	case input.Label == "" || utf8.RuneCountInString(input.Label) > 45:
+		return errors.New("label must be between 1 and 45 characters")
+	case input.MinLength < 0 || input.MinLength > 4000:
+		return errors.New("min length must be between 0 and 4000")
+	case input.MaxLength < 0 || input.MaxLength > 4000:
+		return errors.New("max length must be between 1 and 4000")
+	case input.MaxLength > 0 && input.MinLength > input.MaxLength:
+		return errors.New("min length must not be greater than max length")
+	case utf8.RuneCountInString(input.Placeholder) > 100:
+		return errors.New("placeholder must be at most 100 characters")
+	case utf8.RuneCountInString(input.Value) > 4000 || (input.MaxLength > 0 && utf8.RuneCountInString(input.Value) > input.MaxLength):
+		return errors.New("value is longer than the max length")
+	}
+	return nil
+}
+
+// Responds to the interaction by showing a modal, which can't be done in response to a modal submit
+func (i *Interaction) Modal(m *ModalBuilder) error {
+	data, err := m.Build()
+	if err != nil {
+		return err
+	}
+	return i.Respond(&discord.InteractionResponse{
+		Type: discord.InteractionCallbackTypeModal,
+		Data: data,
+	})
+}
+
+// Returns the values submitted in a modal by the custom IDs of their text inputs
+func ModalValues(i *discord.Interaction) map[string]string {
+	values := make(map[string]string)
+	if i.Data == nil {
+		return values
+	}
+
+	for _, c := range i.Data.Components {
+		row, ok := c.(*discord.ActionRow)
+		if !ok {
+			continue
+		}
+		for _, c := range row.Components {
+			if input, ok := c.(*discord.TextInput); ok {
+				values[input.CustomID] = input.Value
+			}
+		}
+	}
+
+	return values
+}
+
+// Binds the values submitted in a modal into the fields of the struct v points to, tagged with the custom IDs of their
+// text inputs as `input:"custom_id,required"`. Fields can be strings or numbers, which are checked against min and max tags
+func BindModal(i *discord.Interaction, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("modal values must be bound to a pointer to a struct, got %T", v)
+	}
+
+	values := ModalValues(i)
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for n := 0; n < rt.NumField(); n++ {
+		field := rt.Field(n)
+		tag, ok := parseFieldTag(field, "input")
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		value, ok := values[tag.name]
+		if !ok || value == "" {
+			if tag.required {
+				return OptionError{Option: tag.name, Message: "a value is required"}
+			}
+			continue
+		}
+
+		switch field.Type.Kind() {
+		case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		default:
+			return OptionError{Option: tag.name, Message: fmt.Sprintf("unsupported field type %s", field.Type)}
+		}
+		if err := setOption(rv.Field(n), field, value, nil); err != nil {
+			return OptionError{Option: tag.name, Message: err.Error()}
+		}
+	}
+
+	return nil
+}
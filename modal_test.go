@@ -0,0 +1,40 @@
+package eventide
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+func TestModalLimitsCountCharacters(t *testing.T) {
+	// each character is several bytes, so only counting runes keeps these within the limits
+	title := strings.Repeat("é", 45)
+	label := strings.Repeat("日", 45)
+	value := strings.Repeat("🎵", 10)
+
+	_, err := NewModal("modal", title).TextInput(&discord.TextInput{
+		CustomID:  "input",
+		Label:     label,
+		MaxLength: 10,
+		Value:     value,
+	}).Build()
+	if err != nil {
+		t.Fatalf("expected the modal to be valid, got %s", err)
+	}
+
+	_, err = NewModal("modal", title+"é").ShortInput("input", label, true, 0, 0).Build()
+	if err == nil {
+		t.Fatal("expected a 46 character title to be rejected")
+	}
+
+	_, err = NewModal("modal", title).TextInput(&discord.TextInput{
+		CustomID:  "input",
+		Label:     label,
+		MaxLength: 9,
+		Value:     value,
+	}).Build()
+	if err == nil {
+		t.Fatal("expected a value longer than the max length to be rejected")
+	}
+}
@@ -0,0 +1,146 @@
+package eventide
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Reads Opus packets from an Ogg Opus stream, such as a .opus file or the output of
+// ffmpeg -i input -c:a libopus -ar 48000 -ac 2 -f opus -
+type OggOpusSource struct {
+	r io.Reader
+
+	// packets read from the current page that haven't been returned yet
+	packets [][]byte
+	// a packet continued on the next page
+	partial []byte
+	// number of packets read, the first two are the OpusHead and OpusTags headers
+	read int
+}
+
+var (
+	errInvalidOggPage = errors.New("invalid ogg page")
+	oggCapturePattern = []byte("OggS")
+	oggCRCTable       = makeOggCRCTable()
+)
+
+// Creates an audio source reading an Ogg Opus stream, the stream is closed at the end of the track if it's an io.Closer
+func NewOggOpusSource(r io.Reader) *OggOpusSource {
+	return &OggOpusSource{r: r}
+}
+
+// Returns the next Opus packet in the stream, or io.EOF once the stream has ended
+func (s *OggOpusSource) ReadOpus() ([]byte, error) {
+	for {
+		for len(s.packets) > 0 {
+			packet := s.packets[0]
+			s.packets = s.packets[1:]
+			s.read++
+
+			switch s.read {
+			case 1:
+				if !bytes.HasPrefix(packet, []byte("OpusHead")) {
+					return nil, errors.New("stream isn't an ogg opus stream")
+				}
+			case 2:
+				// OpusTags, holds metadata that isn't needed
+			default:
+				return packet, nil
+			}
+		}
+
+		if err := s.readPage(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (s *OggOpusSource) Close() error {
+	if closer, ok := s.r.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Reads an Ogg page and splits its segments into packets
+// https://www.rfc-editor.org/rfc/rfc3533#section-6
+func (s *OggOpusSource) readPage() error {
+	header := make([]byte, 27)
+	if _, err := io.ReadFull(s.r, header); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return errInvalidOggPage
+		}
+		return err
+	}
+	if !bytes.Equal(header[0:4], oggCapturePattern) || header[4] != 0 {
+		return errInvalidOggPage
+	}
+
+	segments := make([]byte, header[26])
+	if _, err := io.ReadFull(s.r, segments); err != nil {
+		return errInvalidOggPage
+	}
+
+	size := 0
+	for _, segment := range segments {
+		size += int(segment)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(s.r, body); err != nil {
+		return errInvalidOggPage
+	}
+
+	// the checksum is calculated with its own field zeroed
+	checksum := binary.LittleEndian.Uint32(header[22:26])
+	binary.LittleEndian.PutUint32(header[22:26], 0)
+	crc := oggCRC(0, header)
+	crc = oggCRC(crc, segments)
+	crc = oggCRC(crc, body)
+	if crc != checksum {
+		return fmt.Errorf("ogg page checksum mismatch, expected %08x got %08x", checksum, crc)
+	}
+
+	// a packet is made up of segments until one shorter than 255 bytes
+	packet := s.partial
+	s.partial = nil
+	offset := 0
+	for _, segment := range segments {
+		packet = append(packet, body[offset:offset+int(segment)]...)
+		offset += int(segment)
+		if segment < 255 {
+			s.packets = append(s.packets, packet)
+			packet = nil
+		}
+	}
+	if packet != nil {
+		s.partial = packet
+	}
+
+	return nil
+}
+
+func makeOggCRCTable() *[256]uint32 {
+	var table [256]uint32
+	for i := range table {
+		crc := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if crc&0x80000000 != 0 {
+				crc = crc<<1 ^ 0x04C11DB7
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return &table
+}
+
+func oggCRC(crc uint32, data []byte) uint32 {
+	for _, b := range data {
+		crc = crc<<8 ^ oggCRCTable[byte(crc>>24)^b]
+	}
+	return crc
+}
@@ -0,0 +1,160 @@
+package eventide
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// Splits packets into segments of up to 255 bytes, ending each packet with a segment shorter than 255 bytes
+func oggLace(packets ...[]byte) (segments []byte, body []byte) {
+	for _, p := range packets {
+		n := len(p)
+		for ; n >= 255; n -= 255 {
+			segments = append(segments, 255)
+		}
+		segments = append(segments, byte(n))
+		body = append(body, p...)
+	}
+	return segments, body
+}
+
+// Builds an Ogg page with a valid checksum
+func oggPage(sequence uint32, headerType byte, segments []byte, body []byte) []byte {
+	page := make([]byte, 27, 27+len(segments)+len(body))
+	copy(page, oggCapturePattern)
+	page[5] = headerType
+	binary.LittleEndian.PutUint32(page[14:], 1)
+	binary.LittleEndian.PutUint32(page[18:], sequence)
+	page[26] = byte(len(segments))
+	page = append(page, segments...)
+	page = append(page, body...)
+
+	binary.LittleEndian.PutUint32(page[22:], oggCRC(0, page))
+	return page
+}
+
+// Returns the pages holding the OpusHead and OpusTags headers
+func oggOpusHeaders() []byte {
+	head := oggPage(0, 0x02, []byte{19}, append([]byte("OpusHead"), make([]byte, 11)...))
+	tags := oggPage(1, 0, []byte{16}, append([]byte("OpusTags"), make([]byte, 8)...))
+	return append(head, tags...)
+}
+
+// Builds a stream with the OpusHead and OpusTags headers followed by a page of audio packets
+func oggOpusStream(packets ...[]byte) []byte {
+	stream := oggOpusHeaders()
+	segments, body := oggLace(packets...)
+	stream = append(stream, oggPage(2, 0x04, segments, body)...)
+	return stream
+}
+
+func readAllOpus(s *OggOpusSource) ([][]byte, error) {
+	var packets [][]byte
+	for {
+		packet, err := s.ReadOpus()
+		if err == io.EOF {
+			return packets, nil
+		} else if err != nil {
+			return packets, err
+		}
+		packets = append(packets, packet)
+	}
+}
+
+func TestOggCRC(t *testing.T) {
+	// check value of CRC-32 with polynomial 0x04C11DB7, no reflection and no initial or final xor
+	if crc := oggCRC(0, []byte("123456789")); crc != 0x89A1897F {
+		t.Fatalf("expected crc 89a1897f, got %08x", crc)
+	}
+
+	stream := oggOpusStream([]byte{1, 2, 3})
+	// corrupt the last byte of the audio packet
+	stream[len(stream)-1] ^= 0xFF
+
+	_, err := readAllOpus(NewOggOpusSource(bytes.NewReader(stream)))
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+}
+
+func TestOggOpusSource(t *testing.T) {
+	packets := [][]byte{
+		{0xFC, 1, 2},
+		bytes.Repeat([]byte{0xAB}, 255),
+		bytes.Repeat([]byte{0xCD}, 600),
+		{0xFC},
+	}
+
+	got, err := readAllOpus(NewOggOpusSource(bytes.NewReader(oggOpusStream(packets...))))
+	if err != nil {
+		t.Fatalf("error reading stream: %s", err)
+	}
+	// the headers aren't returned as audio
+	if len(got) != len(packets) {
+		t.Fatalf("expected %d packets, got %d", len(packets), len(got))
+	}
+	for i := range packets {
+		if !bytes.Equal(got[i], packets[i]) {
+			t.Errorf("packet %d: expected %d bytes, got %d", i, len(packets[i]), len(got[i]))
+		}
+	}
+}
+
+func TestOggOpusSourceContinuedPacket(t *testing.T) {
+	packet := bytes.Repeat([]byte{0xEE}, 700)
+	segments, body := oggLace(packet)
+
+	// the packet's first two segments are on one page and its last segment is continued on the next
+	stream := oggOpusHeaders()
+	stream = append(stream, oggPage(2, 0, segments[:2], body[:510])...)
+	stream = append(stream, oggPage(3, 0x01|0x04, append(segments[2:], 1), append(body[510:], 0xFC))...)
+
+	got, err := readAllOpus(NewOggOpusSource(bytes.NewReader(stream)))
+	if err != nil {
+		t.Fatalf("error reading stream: %s", err)
+	}
+	if len(got) != 2 || !bytes.Equal(got[0], packet) || !bytes.Equal(got[1], []byte{0xFC}) {
+		t.Fatalf("expected the continued packet then a 1 byte packet, got %d packets", len(got))
+	}
+}
+
+func TestOggOpusSourceNotOpus(t *testing.T) {
+	stream := oggPage(0, 0x02, []byte{8}, []byte("OggVorbi"))
+	if _, err := NewOggOpusSource(bytes.NewReader(stream)).ReadOpus(); err == nil || err == io.EOF {
+		t.Fatalf("expected an error for a stream without OpusHead, got %v", err)
+	}
+}
+
+func TestOggOpusSourceTruncated(t *testing.T) {
+	stream := oggOpusStream(bytes.Repeat([]byte{0xFC}, 100))
+	audioPage := len(stream) - (27 + 1 + 100)
+
+	tests := []struct {
+		name string
+		size int
+	}{
+		{name: "in header", size: audioPage + 10},
+		{name: "in segment table", size: audioPage + 27},
+		{name: "in body", size: len(stream) - 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := readAllOpus(NewOggOpusSource(bytes.NewReader(stream[:test.size])))
+			if !errors.Is(err, errInvalidOggPage) {
+				t.Fatalf("expected errInvalidOggPage, got %v", err)
+			}
+		})
+	}
+
+	t.Run("at page boundary", func(t *testing.T) {
+		got, err := readAllOpus(NewOggOpusSource(bytes.NewReader(stream[:audioPage])))
+		if err != nil || len(got) != 0 {
+			t.Fatalf("expected a clean end with no packets, got %d packets and %v", len(got), err)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package eventide
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Minimum interval between presence changes made by RotatePresence, keeping it well within the gateway send rate limit
+const minPresenceInterval = 15 * time.Second
+
+// Updates the client's status and activities. The presence is kept and sent again whenever the client identifies
+// https://discord.com/developers/docs/topics/gateway#update-presence
+func (c *Client) UpdatePresence(status discord.Status, activities ...*discord.Activity) error {
+	if activities == nil {
+		activities = []*discord.Activity{}
+	}
+
+	presence := &discord.GatewayPresenceUpdate{
+		Activities: activities,
+		Status:     status,
+		AFK:        status == discord.StatusIdle,
+	}
+	if presence.AFK {
+		presence.Since = int(time.Now().UnixMilli())
+	}
+
+	c.presenceLock.Lock()
+	c.presence = presence
+	c.presenceLock.Unlock()
+
+	return c.sendPayload(&discord.GatewayPayload[*discord.GatewayPresenceUpdate]{
+		Op:   3,
+		Data: presence,
+	})
+}
+
+// Returns the presence sent when the client identifies, or nil if none has been set
+func (c *Client) Presence() *discord.GatewayPresenceUpdate {
+	c.presenceLock.RLock()
+	defer c.presenceLock.RUnlock()
+	return c.presence
+}
+
+// Cycles through activities with the given status, changing activity every interval until the context is cancelled.
+// Intervals shorter than 15 seconds are raised to 15 seconds so that the rotation doesn't use up the gateway's send
+// rate limit
+func (c *Client) RotatePresence(ctx context.Context, interval time.Duration, status discord.Status, activities ...*discord.Activity) error {
+	if len(activities) == 0 {
+		return errors.New("no activities to rotate through")
+	}
+	if interval < minPresenceInterval {
+		interval = minPresenceInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for i := 0; ; i = (i + 1) % len(activities) {
+		if err := c.UpdatePresence(status, activities[i]); err != nil {
+			// the gateway may be reconnecting, the presence is sent when it identifies
+			c.log(LogWarn, "error rotating presence: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package eventide
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"unicode/utf8"
+)
+
+// Maximum length of an audit log reason, in characters
+const AuditLogReasonLimit = 512
+
+// An option applied to a REST request before it's sent
+type RequestOption func(req *http.Request) error
+
+type auditLogReasonKey struct{}
+
+// Sets the reason shown in the guild's audit log for the action, which can be up to 512 characters
+// https://discord.com/developers/docs/resources/audit-log#audit-log-entry-object
+func WithReason(reason string) RequestOption {
+	return func(req *http.Request) error {
+		if reason == "" {
+			return nil
+		}
+		if n := utf8.RuneCountInString(reason); n > AuditLogReasonLimit {
+			return fmt.Errorf("audit log reason is %d characters long, the limit is %d", n, AuditLogReasonLimit)
+		}
+		// the header is URL encoded so that it can hold any UTF-8 characters
+		req.Header.Set("X-Audit-Log-Reason", url.PathEscape(reason))
+		return nil
+	}
+}
+
+// Returns a context carrying an audit log reason, which is used by requests made with the context
+func WithAuditLogReason(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, auditLogReasonKey{}, reason)
+}
+
+// Sets the request's context, so that it can be cancelled or carry an audit log reason
+func WithContext(ctx context.Context) RequestOption {
+	return func(req *http.Request) error {
+		if reason, ok := ctx.Value(auditLogReasonKey{}).(string); ok {
+			if err := WithReason(reason)(req); err != nil {
+				return err
+			}
+		}
+		*req = *req.WithContext(ctx)
+		return nil
+	}
+}
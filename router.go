@@ -0,0 +1,429 @@
+package eventide
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// Routes application command interactions to handlers by their command path, such as "ban" or "config set volume"
+type CommandRouter struct {
+	routes       map[string]commandRoute
+	autocomplete map[autocompleteKey]AutocompleteHandler
+	commands     []*discord.ApplicationCommand
+	routesLock   sync.RWMutex
+}
+
+type commandRoute struct {
+	callback    reflect.Value
+	optionsType reflect.Type
+}
+
+var interactionType = reflect.TypeOf(&Interaction{})
+
+// Creates a command router, register it with Client.AddHandler(router.HandleInteraction)
+func NewCommandRouter() *CommandRouter {
+	return &CommandRouter{
+		routes: make(map[string]commandRoute),
+	}
+}
+
+// Registers a handler for a command path made up of the command name, and the subcommand group and subcommand names if
+// present, separated by spaces. The handler must be a func(*Interaction) or a func(*Interaction, *T) where T is a
+// struct with option tags that the command's options are bound to
+func (r *CommandRouter) Handle(path string, handler any) error {
+	route, err := newCommandRoute(handler)
+	if err != nil {
+		return err
+	}
+
+	r.routesLock.Lock()
+	r.routes[normalisePath(path)] = route
+	r.routesLock.Unlock()
+
+	return nil
+}
+
+func newCommandRoute(handler any) (commandRoute, error) {
+	v := reflect.ValueOf(handler)
+	if v.Kind() != reflect.Func {
+		return commandRoute{}, errors.New("command handler must be a function")
+	}
+	t := v.Type()
+	if t.NumIn() < 1 || t.NumIn() > 2 || t.In(0) != interactionType {
+		return commandRoute{}, errors.New("command handler must take an *Interaction and optionally a pointer to an options struct")
+	} else if t.NumOut() > 0 {
+		return commandRoute{}, errors.New("command handler must not return anything")
+	}
+
+	route := commandRoute{callback: v}
+	if t.NumIn() == 2 {
+		if t.In(1).Kind() != reflect.Pointer || t.In(1).Elem().Kind() != reflect.Struct {
+			return commandRoute{}, errors.New("command handler options must be a pointer to a struct")
+		}
+		route.optionsType = t.In(1).Elem()
+	}
+
+	return route, nil
+}
+
+func normalisePath(path string) string {
+	return strings.Join(strings.Fields(path), " ")
+}
+
+// Registers a handler for a chat input command, deriving the command's options from the handler's options struct.
+// The derived commands are returned by Commands so they can be passed to Client.SyncCommands
+func (r *CommandRouter) HandleCommand(name string, description string, handler any) error {
+	route, err := newCommandRoute(handler)
+	if err != nil {
+		return err
+	}
+
+	cmd := &discord.ApplicationCommand{
+		Type:        discord.ApplicationCommandTypeChatInput,
+		Name:        name,
+		Description: description,
+	}
+	if route.optionsType != nil {
+		options, err := commandOptions(route.optionsType)
+		if err != nil {
+			return fmt.Errorf("error deriving options for command %s: %s", name, err)
+		}
+		cmd.Options = options
+	}
+
+	r.routesLock.Lock()
+	defer r.routesLock.Unlock()
+	r.routes[normalisePath(name)] = route
+	for n, existing := range r.commands {
+		if existing.Name == name {
+			r.commands[n] = cmd
+			return nil
+		}
+	}
+	r.commands = append(r.commands, cmd)
+
+	return nil
+}
+
+// Returns the commands registered with HandleCommand
+func (r *CommandRouter) Commands() []*discord.ApplicationCommand {
+	r.routesLock.RLock()
+	defer r.routesLock.RUnlock()
+	return append([]*discord.ApplicationCommand(nil), r.commands...)
+}
+
+// Runs the handler for an application command interaction, replying with an ephemeral error if its options can't be bound.
+// Autocomplete interactions are passed to the handler registered for the focused option
+func (r *CommandRouter) HandleInteraction(i *Interaction) {
+	if i.Data == nil {
+		return
+	}
+	if i.Type == discord.InteractionTypeApplicationCommandAutocomplete {
+		r.handleAutocomplete(i)
+		return
+	}
+	if i.Type != discord.InteractionTypeApplicationCommmand {
+		return
+	}
+
+	route, ok := r.route(commandPath(i.Data))
+	if !ok {
+		return
+	}
+
+	args := []reflect.Value{reflect.ValueOf(i)}
+	if route.optionsType != nil {
+		opts := reflect.New(route.optionsType)
+		if err := BindOptions(i.Interaction, opts.Interface()); err != nil {
+			i.Reply(&discord.InteractionCallbackData{
+				Content: err.Error(),
+				Flags:   discord.MessageFlagsEphemeral,
+			})
+			return
+		}
+		args = append(args, opts)
+	}
+
+	route.callback.Call(args)
+}
+
+// Finds the route for the longest registered prefix of a command path, so a handler for a parent command can bind
+// the options of its subcommands
+func (r *CommandRouter) route(path string) (commandRoute, bool) {
+	r.routesLock.RLock()
+	defer r.routesLock.RUnlock()
+
+	for {
+		if route, ok := r.routes[path]; ok {
+			return route, true
+		}
+		i := strings.LastIndex(path, " ")
+		if i == -1 {
+			return commandRoute{}, false
+		}
+		path = path[:i]
+	}
+}
+
+// Returns the full command path of the invoked command, made up of the command, subcommand group and subcommand names
+func commandPath(data *discord.InteractionData) string {
+	path := []string{data.Name}
+	options := data.Options
+
+	for len(options) == 1 && isSubCommand(options[0]) {
+		path = append(path, options[0].Name)
+		options = options[0].Options
+	}
+
+	return strings.Join(path, " ")
+}
+
+// An error binding an application command option
+type OptionError struct {
+	Option  string
+	Message string
+}
+
+func (e OptionError) Error() string {
+	return fmt.Sprintf("invalid option %s: %s", e.Option, e.Message)
+}
+
+type optionTag struct {
+	name         string
+	required     bool
+	autocomplete bool
+}
+
+// Parses a struct field's option tag in the form `option:"name,required,autocomplete"`
+func parseOptionTag(field reflect.StructField) (optionTag, bool) {
+	return parseFieldTag(field, "option")
+}
+
+func parseFieldTag(field reflect.StructField, key string) (optionTag, bool) {
+	tag, ok := field.Tag.Lookup(key)
+	if !ok || tag == "-" {
+		return optionTag{}, false
+	}
+
+	parts := strings.Split(tag, ",")
+	opt := optionTag{name: parts[0]}
+	if opt.name == "" {
+		opt.name = strings.ToLower(field.Name)
+	}
+	for _, p := range parts[1:] {
+		switch p {
+		case "required":
+			opt.required = true
+		case "autocomplete":
+			opt.autocomplete = true
+		}
+	}
+
+	return opt, true
+}
+
+// Binds the options of an application command interaction into the option tagged fields of the struct v points to.
+// Supported field types are string, integers, floats, bool, *discord.User, *discord.GuildMember, *discord.Channel,
+// *discord.Role and *discord.Attachment. Numeric fields are checked against min and max tags if present.
+// Fields that are pointers to structs are subcommands or subcommand groups, and are only set if invoked
+func BindOptions(i *discord.Interaction, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("options must be a pointer to a struct, got %T", v)
+	}
+	if i.Data == nil {
+		return fmt.Errorf("interaction has no data")
+	}
+
+	resolved := i.Data.Resolved
+	if resolved == nil {
+		resolved = &discord.ResolvedData{}
+	}
+
+	return bindOptions(rv.Elem(), i.Data.Options, resolved)
+}
+
+func bindOptions(rv reflect.Value, options []*discord.ApplicationCommandInteractionDataOption, resolved *discord.ResolvedData) error {
+	rt := rv.Type()
+
+	if len(options) == 1 && isSubCommand(options[0]) {
+		for n := 0; n < rt.NumField(); n++ {
+			field := rt.Field(n)
+			tag, ok := parseOptionTag(field)
+			if !ok || !field.IsExported() || tag.name != options[0].Name || !isSubCommandField(field) {
+				continue
+			}
+
+			sub := reflect.New(field.Type.Elem())
+			rv.Field(n).Set(sub)
+			return bindOptions(sub.Elem(), options[0].Options, resolved)
+		}
+
+		// the struct is bound to the invoked subcommand itself
+		return bindOptions(rv, options[0].Options, resolved)
+	}
+
+	values := make(map[string]*discord.ApplicationCommandInteractionDataOption, len(options))
+	for _, o := range options {
+		values[o.Name] = o
+	}
+
+	for n := 0; n < rt.NumField(); n++ {
+		field := rt.Field(n)
+		tag, ok := parseOptionTag(field)
+		if !ok || !field.IsExported() || isSubCommandField(field) {
+			continue
+		}
+
+		o, ok := values[tag.name]
+		if !ok || o.Value == nil {
+			if tag.required {
+				return OptionError{Option: tag.name, Message: "a value is required"}
+			}
+			continue
+		}
+
+		if err := setOption(rv.Field(n), field, o.Value, resolved); err != nil {
+			return OptionError{Option: tag.name, Message: err.Error()}
+		}
+	}
+
+	return nil
+}
+
+func isSubCommand(o *discord.ApplicationCommandInteractionDataOption) bool {
+	t := discord.ApplicationCommandOptionType(o.Type)
+	return t == discord.ApplicationCommandOptionTypeSubCommand || t == discord.ApplicationCommandOptionTypeSubCommandGroup
+}
+
+// Fields that are pointers to structs other than the resolved Discord types are subcommands
+func isSubCommandField(field reflect.StructField) bool {
+	switch field.Type {
+	case userType, memberType, channelType, roleType, attachmentType:
+		return false
+	}
+	return field.Type.Kind() == reflect.Pointer && field.Type.Elem().Kind() == reflect.Struct
+}
+
+var (
+	userType        = reflect.TypeOf(&discord.User{})
+	memberType      = reflect.TypeOf(&discord.GuildMember{})
+	channelType     = reflect.TypeOf(&discord.Channel{})
+	roleType        = reflect.TypeOf(&discord.Role{})
+	attachmentType  = reflect.TypeOf(&discord.Attachment{})
+	errUnresolvable = errors.New("could not be resolved")
+)
+
+func setOption(f reflect.Value, field reflect.StructField, value any, resolved *discord.ResolvedData) error {
+	switch f.Type() {
+	case userType, memberType, channelType, roleType, attachmentType:
+		id, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected an ID, got %v", value)
+		}
+
+		var res any
+		var found bool
+		switch f.Type() {
+		case userType:
+			res, found = resolved.Users[id]
+		case memberType:
+			var member *discord.GuildMember
+			if member, found = resolved.Members[id]; found {
+				if member.User == nil {
+					member.User = resolved.Users[id]
+				}
+				res = member
+			}
+		case channelType:
+			res, found = resolved.Channels[id]
+		case roleType:
+			res, found = resolved.Roles[id]
+		case attachmentType:
+			res, found = resolved.Attachments[id]
+		}
+		if !found {
+			return errUnresolvable
+		}
+		f.Set(reflect.ValueOf(res))
+		return nil
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a string, got %v", value)
+		}
+		f.SetString(s)
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("expected a boolean, got %v", value)
+		}
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := optionNumber(value)
+		if err != nil {
+			return err
+		}
+		if n != float64(int64(n)) {
+			return fmt.Errorf("expected an integer, got %v", value)
+		}
+		if err := checkRange(field, n); err != nil {
+			return err
+		}
+		if f.OverflowInt(int64(n)) {
+			return fmt.Errorf("%v is out of range", value)
+		}
+		f.SetInt(int64(n))
+	case reflect.Float32, reflect.Float64:
+		n, err := optionNumber(value)
+		if err != nil {
+			return err
+		}
+		if err := checkRange(field, n); err != nil {
+			return err
+		}
+		f.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", f.Type())
+	}
+
+	return nil
+}
+
+func optionNumber(value any) (float64, error) {
+	switch n := value.(type) {
+	case float64:
+		return n, nil
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return 0, fmt.Errorf("expected a number, got %q", n)
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("expected a number, got %v", value)
+}
+
+// Checks a number against the field's min and max tags
+func checkRange(field reflect.StructField, n float64) error {
+	if min, ok := field.Tag.Lookup("min"); ok {
+		if m, err := strconv.ParseFloat(min, 64); err == nil && n < m {
+			return fmt.Errorf("must be at least %s", min)
+		}
+	}
+	if max, ok := field.Tag.Lookup("max"); ok {
+		if m, err := strconv.ParseFloat(max, 64); err == nil && n > m {
+			return fmt.Errorf("must be at most %s", max)
+		}
+	}
+	return nil
+}
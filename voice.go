@@ -0,0 +1,763 @@
+package eventide
+
+import (
+	"context"
+	"crypto/cipher"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thefakequake/eventide/discord"
+)
+
+// How long to wait for Discord to send the voice state and voice server updates when joining a channel
+const voiceJoinTimeout = 10 * time.Second
+
+// How long to wait before reconnecting when a voice connection can't be resumed, doubled after each failed attempt
+// up to maxVoiceReconnectDelay
+const voiceReconnectDelay = time.Second
+
+const maxVoiceReconnectDelay = 30 * time.Second
+
+// How many times reconnecting is attempted before the connection is given up on and disconnected
+const maxVoiceReconnectAttempts = 5
+
+// A connection to a guild's voice server, used to send and receive Opus audio in a voice channel
+type VoiceConnection struct {
+	sync.RWMutex
+
+	GuildID   string
+	ChannelID string
+	UserID    string
+	SessionID string
+
+	// Token and endpoint of the voice server, from the voice server update
+	Token    string
+	Endpoint string
+
+	// SSRC the connection's audio is sent with
+	SSRC uint32
+
+	client *Client
+
+	ws     *websocket.Conn
+	wsLock sync.Mutex
+	// closed when the current websocket is replaced or closed, stopping its heartbeat and listen goroutines
+	wsStop chan struct{}
+	closed chan struct{}
+	// delay before the first reconnect attempt when resuming fails
+	reconnectDelay time.Duration
+
+	stateUpdates  chan *discord.VoiceState
+	serverUpdates chan *discord.VoiceServerUpdateEvent
+
+	udp       *net.UDPConn
+	mode      string
+	aead      cipher.AEAD
+	sendLock  sync.Mutex
+	sequence  uint16
+	timestamp uint32
+	nonce     uint32
+	speaking  bool
+
+	ssrcUsers   map[uint32]string
+	receivers   map[string]*voiceReceiver
+	receiveLock sync.Mutex
+}
+
+// Creates a voice connection from the session ID of the user's voice state and a voice server update, call Open to
+// connect to the voice server. JoinVoice should be used to join a channel through the gateway
+func NewVoiceConnection(userID string, sessionID string, server *discord.VoiceServerUpdateEvent) *VoiceConnection {
+	return &VoiceConnection{
+		GuildID:   server.GuildID,
+		UserID:    userID,
+		SessionID: sessionID,
+		Token:     server.Token,
+		Endpoint:  server.Endpoint,
+		closed:    make(chan struct{}),
+
+		reconnectDelay: voiceReconnectDelay,
+	}
+}
+
+// Joins a voice channel, waiting for Discord to send the voice server details before connecting to it
+func (c *Client) JoinVoice(guildID string, channelID string) (*VoiceConnection, error) {
+	c.RLock()
+	user := c.User
+	c.RUnlock()
+	if user == nil {
+		return nil, errors.New("client must be connected to the gateway to join voice")
+	}
+
+	v := &VoiceConnection{
+		GuildID:       guildID,
+		ChannelID:     channelID,
+		UserID:        user.ID,
+		client:        c,
+		closed:        make(chan struct{}),
+		stateUpdates:  make(chan *discord.VoiceState, 1),
+		serverUpdates: make(chan *discord.VoiceServerUpdateEvent, 1),
+	}
+
+	c.voiceLock.Lock()
+	if existing, ok := c.voice[guildID]; ok {
+		c.voiceLock.Unlock()
+		if err := existing.Disconnect(); err != nil {
+			c.log(LogWarn, "error disconnecting existing voice connection: %s", err)
+		}
+		c.voiceLock.Lock()
+	}
+	c.voice[guildID] = v
+	c.voiceLock.Unlock()
+
+	fail := func(err error) (*VoiceConnection, error) {
+		c.voiceLock.Lock()
+		if c.voice[guildID] == v {
+			delete(c.voice, guildID)
+		}
+		c.voiceLock.Unlock()
+		return nil, err
+	}
+
+	if err := c.updateVoiceState(guildID, &channelID); err != nil {
+		return fail(fmt.Errorf("error sending voice state update: %s", err))
+	}
+	c.log(LogInfo, "sent opcode 4 voice state update for guild %s", guildID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), voiceJoinTimeout)
+	defer cancel()
+
+	var state *discord.VoiceState
+	var server *discord.VoiceServerUpdateEvent
+	for state == nil || server == nil {
+		select {
+		case state = <-v.stateUpdates:
+		case server = <-v.serverUpdates:
+		case <-ctx.Done():
+			c.updateVoiceState(guildID, nil)
+			return fail(errors.New("timed out waiting for voice server"))
+		}
+	}
+
+	v.Lock()
+	v.SessionID = state.SessionID
+	v.Token = server.Token
+	v.Endpoint = server.Endpoint
+	v.Unlock()
+
+	if err := v.Open(ctx); err != nil {
+		c.updateVoiceState(guildID, nil)
+		return fail(fmt.Errorf("error connecting to voice server: %s", err))
+	}
+
+	return v, nil
+}
+
+// Returns the voice connection for a guild, or nil if the client isn't connected to voice in it
+func (c *Client) VoiceConnection(guildID string) *VoiceConnection {
+	c.voiceLock.RLock()
+	defer c.voiceLock.RUnlock()
+	return c.voice[guildID]
+}
+
+// Sends an opcode 4 voice state update, leaving voice if channelID is nil
+func (c *Client) updateVoiceState(guildID string, channelID *string) error {
+	payload := discord.GatewayPayload[discord.GatewayVoiceStateUpdate]{
+		Op: 4,
+		Data: discord.GatewayVoiceStateUpdate{
+			GuildID:   guildID,
+			ChannelID: channelID,
+		},
+	}
+
+	return c.sendPayload(&payload)
+}
+
+// Passes voice state and server updates to the voice connection waiting on them
+func (c *Client) handleVoiceStateUpdate(e *discord.VoiceStateUpdateEvent) {
+	c.RLock()
+	user := c.User
+	c.RUnlock()
+	if user == nil || e.UserID != user.ID {
+		return
+	}
+
+	v := c.VoiceConnection(e.GuildID)
+	if v == nil || v.stateUpdates == nil {
+		return
+	}
+
+	v.Lock()
+	v.SessionID = e.SessionID
+	if e.ChannelID != "" {
+		v.ChannelID = e.ChannelID
+	}
+	v.Unlock()
+
+	select {
+	case v.stateUpdates <- e.VoiceState:
+	default:
+	}
+}
+
+func (c *Client) handleVoiceServerUpdate(e *discord.VoiceServerUpdateEvent) {
+	v := c.VoiceConnection(e.GuildID)
+	if v == nil || v.serverUpdates == nil {
+		return
+	}
+	if e.Endpoint == "" {
+		// the voice server is unavailable, another update is sent once one is allocated
+		return
+	}
+
+	select {
+	case v.serverUpdates <- e:
+	default:
+	}
+
+	// an update for an established connection means the voice server has moved
+	v.RLock()
+	connected := v.ws != nil
+	v.RUnlock()
+	if connected {
+		go v.moveServer(e)
+	}
+}
+
+func (v *VoiceConnection) moveServer(e *discord.VoiceServerUpdateEvent) {
+	// drain the update so it isn't mistaken for the initial one
+	select {
+	case <-v.serverUpdates:
+	default:
+	}
+
+	v.log(LogInfo, "voice server moved, reconnecting")
+	v.closeConnections()
+
+	v.Lock()
+	v.Token = e.Token
+	v.Endpoint = e.Endpoint
+	v.closed = make(chan struct{})
+	v.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), voiceJoinTimeout)
+	defer cancel()
+	if err := v.Open(ctx); err != nil {
+		v.log(LogError, "error reconnecting to voice server: %s", err)
+	}
+}
+
+// Connects to the voice server, performs UDP IP discovery and negotiates the encryption mode
+func (v *VoiceConnection) Open(ctx context.Context) error {
+	v.RLock()
+	endpoint := v.Endpoint
+	identify := discord.VoiceIdentify{
+		ServerID:  v.GuildID,
+		UserID:    v.UserID,
+		SessionID: v.SessionID,
+		Token:     v.Token,
+	}
+	v.RUnlock()
+
+	ws, err := v.dial(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		ws.SetReadDeadline(deadline)
+	}
+
+	if err := ws.WriteJSON(&discord.GatewayPayload[discord.VoiceIdentify]{Op: 0, Data: identify}); err != nil {
+		ws.Close()
+		return fmt.Errorf("error sending voice identify payload: %s", err)
+	}
+	v.log(LogInfo, "sent voice identify payload")
+
+	var hello *discord.VoiceHello
+	var ready *discord.VoiceReady
+	for hello == nil || ready == nil {
+		payload, err := readVoicePayload(ws)
+		if err != nil {
+			ws.Close()
+			return err
+		}
+
+		switch payload.Op {
+		case 2:
+			ready = &discord.VoiceReady{}
+			err = json.Unmarshal(payload.Data, ready)
+			v.log(LogInfo, "received voice opcode 2 ready")
+		case 8:
+			hello = &discord.VoiceHello{}
+			err = json.Unmarshal(payload.Data, hello)
+			v.log(LogInfo, "received voice opcode 8 hello")
+		}
+		if err != nil {
+			ws.Close()
+			return fmt.Errorf("error decoding voice payload: %s", err)
+		}
+	}
+
+	mode, err := selectVoiceMode(ready.Modes)
+	if err != nil {
+		ws.Close()
+		return err
+	}
+
+	udp, err := net.DialUDP("udp", nil, &net.UDPAddr{IP: net.ParseIP(ready.IP), Port: ready.Port})
+	if err != nil {
+		ws.Close()
+		return fmt.Errorf("error connecting to voice udp server: %s", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		udp.SetReadDeadline(deadline)
+	}
+
+	address, port, err := discoverIP(udp, ready.SSRC)
+	if err != nil {
+		ws.Close()
+		udp.Close()
+		return fmt.Errorf("error discovering external address: %s", err)
+	}
+	udp.SetReadDeadline(time.Time{})
+	v.log(LogInfo, "discovered external address %s:%d", address, port)
+
+	err = ws.WriteJSON(&discord.GatewayPayload[discord.VoiceSelectProtocol]{
+		Op: 1,
+		Data: discord.VoiceSelectProtocol{
+			Protocol: "udp",
+			Data: &discord.VoiceSelectProtocolData{
+				Address: address,
+				Port:    port,
+				Mode:    mode,
+			},
+		},
+	})
+	if err != nil {
+		ws.Close()
+		udp.Close()
+		return fmt.Errorf("error sending select protocol payload: %s", err)
+	}
+
+	var session discord.VoiceSessionDescription
+	for {
+		payload, err := readVoicePayload(ws)
+		if err != nil {
+			ws.Close()
+			udp.Close()
+			return err
+		}
+		if payload.Op != 4 {
+			continue
+		}
+		if err := json.Unmarshal(payload.Data, &session); err != nil {
+			ws.Close()
+			udp.Close()
+			return fmt.Errorf("error decoding session description: %s", err)
+		}
+		v.log(LogInfo, "received voice opcode 4 session description")
+		break
+	}
+	ws.SetReadDeadline(time.Time{})
+
+	aead, err := newVoiceCipher(session.Mode, session.SecretKey)
+	if err != nil {
+		ws.Close()
+		udp.Close()
+		return err
+	}
+
+	stop := make(chan struct{})
+
+	v.Lock()
+	select {
+	case <-v.closed:
+		// disconnected while connecting
+		v.Unlock()
+		ws.Close()
+		udp.Close()
+		return errors.New("voice connection was closed while connecting")
+	default:
+	}
+	v.stopWebsocket()
+	v.ws = ws
+	v.wsStop = stop
+	v.udp = udp
+	v.SSRC = ready.SSRC
+	v.mode = session.Mode
+	v.aead = aead
+	closed := v.closed
+	v.Unlock()
+
+	v.sendLock.Lock()
+	v.speaking = false
+	v.sendLock.Unlock()
+
+	go v.heartbeatLoop(ws, hello.HeartbeatInterval, stop)
+	go v.listen(ws, stop)
+	go v.receiveLoop(udp, aead, closed)
+
+	return nil
+}
+
+func (v *VoiceConnection) dial(ctx context.Context, endpoint string) (*websocket.Conn, error) {
+	if endpoint == "" {
+		return nil, errors.New("voice server endpoint is empty")
+	}
+	// endpoints are sent without a scheme, one can be given to connect to a local server
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "wss://" + endpoint
+	}
+
+	dialer := websocket.DefaultDialer
+	if v.client != nil {
+		dialer = v.client.dialer
+	}
+
+	ws, _, err := dialer.DialContext(ctx, strings.TrimSuffix(endpoint, "/")+"/?v=4", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to voice websocket: %s", err)
+	}
+	v.log(LogInfo, "established connection with voice websocket")
+
+	return ws, nil
+}
+
+func readVoicePayload(ws *websocket.Conn) (discord.GatewayPayload[json.RawMessage], error) {
+	var payload discord.GatewayPayload[json.RawMessage]
+	if err := ws.ReadJSON(&payload); err != nil {
+		return payload, fmt.Errorf("error reading voice websocket message: %s", err)
+	}
+	return payload, nil
+}
+
+func (v *VoiceConnection) writeJSON(payload any) error {
+	v.RLock()
+	ws := v.ws
+	v.RUnlock()
+	if ws == nil {
+		return errors.New("voice websocket is not open")
+	}
+
+	v.wsLock.Lock()
+	defer v.wsLock.Unlock()
+	return ws.WriteJSON(payload)
+}
+
+func (v *VoiceConnection) heartbeatLoop(ws *websocket.Conn, interval float64, stop chan struct{}) {
+	ticker := time.NewTicker(time.Duration(interval * float64(time.Millisecond)))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+
+		v.wsLock.Lock()
+		err := ws.WriteJSON(&discord.GatewayPayload[int64]{Op: 3, Data: time.Now().UnixMilli()})
+		v.wsLock.Unlock()
+		if err != nil {
+			v.log(LogError, "error sending voice heartbeat: %s", err)
+			continue
+		}
+		v.log(LogDebug, "sent voice heartbeat")
+	}
+}
+
+func (v *VoiceConnection) listen(ws *websocket.Conn, stop chan struct{}) {
+	for {
+		payload, err := readVoicePayload(ws)
+		if err != nil {
+			select {
+			case <-stop:
+			default:
+				v.log(LogWarn, "%s", err)
+				go v.resume()
+			}
+			return
+		}
+
+		v.handlePayload(payload)
+	}
+}
+
+func (v *VoiceConnection) handlePayload(payload discord.GatewayPayload[json.RawMessage]) {
+	switch payload.Op {
+	case 5, 13:
+		v.handleSpeaking(payload)
+	case 6:
+		v.log(LogDebug, "received voice heartbeat ack")
+	case 9:
+		v.log(LogInfo, "resumed voice connection")
+	}
+}
+
+// Reconnects to the voice websocket after it was unexpectedly closed, keeping the UDP connection. Falls back to a full
+// reconnect if the session can't be resumed
+func (v *VoiceConnection) resume() {
+	if err := v.tryResume(); err != nil {
+		v.log(LogWarn, "error resuming voice connection, reconnecting: %s", err)
+		v.reconnect()
+	}
+}
+
+func (v *VoiceConnection) tryResume() error {
+	v.RLock()
+	endpoint := v.Endpoint
+	resume := discord.VoiceResume{
+		ServerID:  v.GuildID,
+		SessionID: v.SessionID,
+		Token:     v.Token,
+	}
+	v.RUnlock()
+
+	// stop the dead websocket's heartbeats before its replacement is connected
+	v.Lock()
+	v.stopWebsocket()
+	closed := v.closed
+	v.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), voiceJoinTimeout)
+	defer cancel()
+
+	ws, err := v.dial(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	if err := ws.WriteJSON(&discord.GatewayPayload[discord.VoiceResume]{Op: 7, Data: resume}); err != nil {
+		ws.Close()
+		return fmt.Errorf("error sending voice resume payload: %s", err)
+	}
+
+	ws.SetReadDeadline(time.Now().Add(voiceJoinTimeout))
+	payload, err := readVoicePayload(ws)
+	if err != nil {
+		ws.Close()
+		return err
+	}
+	if payload.Op != 8 {
+		ws.Close()
+		return fmt.Errorf("expected voice opcode 8 hello, instead received opcode %d", payload.Op)
+	}
+	ws.SetReadDeadline(time.Time{})
+
+	var hello discord.VoiceHello
+	if err := json.Unmarshal(payload.Data, &hello); err != nil {
+		ws.Close()
+		return fmt.Errorf("error decoding voice hello: %s", err)
+	}
+
+	stop := make(chan struct{})
+
+	v.Lock()
+	select {
+	case <-closed:
+		// disconnected while resuming
+		v.Unlock()
+		ws.Close()
+		return nil
+	default:
+	}
+	if v.ws != nil {
+		v.ws.Close()
+	}
+	v.ws = ws
+	v.wsStop = stop
+	v.Unlock()
+
+	go v.heartbeatLoop(ws, hello.HeartbeatInterval, stop)
+	go v.listen(ws, stop)
+
+	return nil
+}
+
+// Closes the websocket and UDP connection and opens new ones, retrying with backoff. The connection is disconnected
+// if every attempt fails
+func (v *VoiceConnection) reconnect() {
+	v.Lock()
+	select {
+	case <-v.closed:
+		// disconnected while resuming
+		v.Unlock()
+		return
+	default:
+	}
+	close(v.closed)
+	v.stopWebsocket()
+	if v.ws != nil {
+		v.ws.Close()
+		v.ws = nil
+	}
+	if v.udp != nil {
+		v.udp.Close()
+		v.udp = nil
+	}
+	closed := make(chan struct{})
+	v.closed = closed
+	delay := v.reconnectDelay
+	v.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-closed:
+			return
+		case <-time.After(delay):
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), voiceJoinTimeout)
+		err := v.Open(ctx)
+		cancel()
+		if err == nil {
+			v.log(LogInfo, "reconnected to voice server")
+			return
+		}
+
+		if attempt == maxVoiceReconnectAttempts {
+			v.log(LogError, "error reconnecting to voice server, disconnecting after %d attempts: %s", attempt, err)
+			v.Disconnect()
+			return
+		}
+
+		delay *= 2
+		if delay > maxVoiceReconnectDelay {
+			delay = maxVoiceReconnectDelay
+		}
+		v.log(LogWarn, "error reconnecting to voice server, retrying in %s: %s", delay, err)
+	}
+}
+
+// Stops the current websocket's goroutines, must be called with the connection locked
+func (v *VoiceConnection) stopWebsocket() {
+	if v.wsStop == nil {
+		return
+	}
+	select {
+	case <-v.wsStop:
+	default:
+		close(v.wsStop)
+	}
+}
+
+// Sets whether the connection is speaking, which must be true while audio is sent
+func (v *VoiceConnection) Speaking(speaking bool) error {
+	v.sendLock.Lock()
+	defer v.sendLock.Unlock()
+	return v.setSpeaking(speaking)
+}
+
+func (v *VoiceConnection) setSpeaking(speaking bool) error {
+	var flags discord.SpeakingFlags
+	if speaking {
+		flags = discord.SpeakingFlagsMicrophone
+	}
+
+	v.RLock()
+	ssrc := v.SSRC
+	v.RUnlock()
+
+	err := v.writeJSON(&discord.GatewayPayload[discord.VoiceSpeaking]{
+		Op: 5,
+		Data: discord.VoiceSpeaking{
+			Speaking: flags,
+			SSRC:     ssrc,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error sending speaking payload: %s", err)
+	}
+	v.speaking = speaking
+
+	return nil
+}
+
+// Sends an Opus packet, marking the connection as speaking if it isn't already. Packets must be written at the rate
+// they are played back, which AudioPlayer takes care of
+func (v *VoiceConnection) WriteOpus(frame []byte) error {
+	v.sendLock.Lock()
+	defer v.sendLock.Unlock()
+
+	if !v.speaking {
+		if err := v.setSpeaking(true); err != nil {
+			return err
+		}
+	}
+
+	v.RLock()
+	udp := v.udp
+	aead := v.aead
+	ssrc := v.SSRC
+	v.RUnlock()
+	if udp == nil {
+		return errors.New("voice connection is not open")
+	}
+
+	packet := sealVoicePacket(aead, rtpHeader(v.sequence, v.timestamp, ssrc), frame, v.nonce)
+	v.sequence++
+	v.timestamp += uint32(opusPacketDuration(frame) * opusSampleRate / time.Second)
+	v.nonce++
+
+	if _, err := udp.Write(packet); err != nil {
+		return fmt.Errorf("error sending voice packet: %s", err)
+	}
+
+	return nil
+}
+
+// Closes the voice websocket and UDP connection without leaving the channel
+func (v *VoiceConnection) closeConnections() {
+	v.Lock()
+	defer v.Unlock()
+
+	select {
+	case <-v.closed:
+	default:
+		close(v.closed)
+	}
+	v.stopWebsocket()
+
+	if v.ws != nil {
+		v.wsLock.Lock()
+		v.ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		v.wsLock.Unlock()
+		v.ws.Close()
+		v.ws = nil
+	}
+	if v.udp != nil {
+		v.udp.Close()
+		v.udp = nil
+	}
+}
+
+// Leaves the voice channel and closes the connection to the voice server
+func (v *VoiceConnection) Disconnect() error {
+	v.closeConnections()
+	v.closeReceivers()
+
+	if v.client == nil {
+		return nil
+	}
+
+	v.client.voiceLock.Lock()
+	if v.client.voice[v.GuildID] == v {
+		delete(v.client.voice, v.GuildID)
+	}
+	v.client.voiceLock.Unlock()
+
+	return v.client.updateVoiceState(v.GuildID, nil)
+}
+
+// Logs through the client, connections opened without one only log warnings and errors
+func (v *VoiceConnection) log(level LogLevel, message string, a ...any) {
+	if v.client != nil {
+		v.client.log(level, message, a...)
+	} else if level <= LogWarn {
+		Logger(level, message, a...)
+	}
+}
@@ -0,0 +1,341 @@
+package eventide
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thefakequake/eventide/discord"
+)
+
+// A local voice server that accepts a connection, optionally dropping it after the first heartbeat to force a resume
+type fakeVoiceServer struct {
+	http      *httptest.Server
+	udp       *net.UDPConn
+	key       [32]byte
+	packets   chan []byte
+	dropFirst bool
+
+	lock       sync.Mutex
+	identify   *discord.VoiceIdentify
+	identifies int
+	protocol   *discord.VoiceSelectProtocol
+	resumed    chan discord.VoiceResume
+	beats      chan int
+	conns      int
+	// close the websocket instead of resuming, as Discord does when the session is no longer valid
+	rejectResume bool
+	// close the websocket instead of identifying after this many identifies, if set
+	maxIdentifies int
+}
+
+func newFakeVoiceServer(t *testing.T, dropFirst bool) *fakeVoiceServer {
+	udp, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &fakeVoiceServer{
+		udp:       udp,
+		key:       [32]byte{1, 2, 3, 4},
+		packets:   make(chan []byte, 256),
+		dropFirst: dropFirst,
+		resumed:   make(chan discord.VoiceResume, 1),
+		beats:     make(chan int, 16),
+	}
+	s.http = httptest.NewServer(http.HandlerFunc(s.serveWebsocket))
+	go s.serveUDP()
+
+	t.Cleanup(func() {
+		s.http.Close()
+		s.udp.Close()
+	})
+	return s
+}
+
+func (s *fakeVoiceServer) endpoint() string {
+	return strings.Replace(s.http.URL, "http", "ws", 1)
+}
+
+// Opens a voice connection to the server
+func (s *fakeVoiceServer) connect(t *testing.T) *VoiceConnection {
+	v := NewVoiceConnection("user", "session", &discord.VoiceServerUpdateEvent{
+		GuildID:  "guild",
+		Token:    "token",
+		Endpoint: s.endpoint(),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := v.Open(ctx); err != nil {
+		t.Fatalf("error opening voice connection: %s", err)
+	}
+	t.Cleanup(func() { v.Disconnect() })
+
+	return v
+}
+
+// Decrypts the Opus frame of a packet sent by the client
+func (s *fakeVoiceServer) decrypt(packet []byte) ([]byte, error) {
+	block, err := aes.NewCipher(s.key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	copy(nonce, packet[len(packet)-voiceNonceSize:])
+
+	return aead.Open(nil, nonce, packet[rtpHeaderSize:len(packet)-voiceNonceSize], packet[:rtpHeaderSize])
+}
+
+func (s *fakeVoiceServer) serveUDP() {
+	buf := make([]byte, 2048)
+	for {
+		n, addr, err := s.udp.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+
+		// ip discovery request
+		if n == 74 && binary.BigEndian.Uint16(buf) == 1 {
+			res := make([]byte, 74)
+			binary.BigEndian.PutUint16(res, 2)
+			binary.BigEndian.PutUint16(res[2:], 70)
+			copy(res[8:], addr.IP.String())
+			binary.BigEndian.PutUint16(res[72:], uint16(addr.Port))
+			s.udp.WriteToUDP(res, addr)
+			continue
+		}
+
+		s.packets <- append([]byte(nil), buf[:n]...)
+	}
+}
+
+func (s *fakeVoiceServer) serveWebsocket(w http.ResponseWriter, r *http.Request) {
+	ws, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer ws.Close()
+
+	s.lock.Lock()
+	s.conns++
+	conn := s.conns
+	s.lock.Unlock()
+
+	for {
+		var payload discord.GatewayPayload[json.RawMessage]
+		if err := ws.ReadJSON(&payload); err != nil {
+			return
+		}
+
+		switch payload.Op {
+		case 0:
+			var identify discord.VoiceIdentify
+			json.Unmarshal(payload.Data, &identify)
+			s.lock.Lock()
+			s.identify = &identify
+			s.identifies++
+			rejected := s.maxIdentifies > 0 && s.identifies > s.maxIdentifies
+			s.lock.Unlock()
+			if rejected {
+				return
+			}
+
+			ws.WriteJSON(map[string]any{"op": 8, "d": map[string]any{"heartbeat_interval": 50}})
+			ws.WriteJSON(map[string]any{"op": 2, "d": map[string]any{
+				"ssrc":  7,
+				"ip":    "127.0.0.1",
+				"port":  s.udp.LocalAddr().(*net.UDPAddr).Port,
+				"modes": []string{"xsalsa20_poly1305", "aead_aes256_gcm_rtpsize"},
+			}})
+		case 1:
+			var protocol discord.VoiceSelectProtocol
+			json.Unmarshal(payload.Data, &protocol)
+			s.lock.Lock()
+			s.protocol = &protocol
+			s.lock.Unlock()
+
+			key := make([]int, len(s.key))
+			for i, b := range s.key {
+				key[i] = int(b)
+			}
+			ws.WriteJSON(map[string]any{"op": 4, "d": map[string]any{"mode": "aead_aes256_gcm_rtpsize", "secret_key": key}})
+		case 3:
+			select {
+			case s.beats <- conn:
+			default:
+			}
+			// drop the first connection so the client has to resume
+			if s.dropFirst && conn == 1 {
+				return
+			}
+			ws.WriteJSON(map[string]any{"op": 6, "d": payload.Data})
+		case 7:
+			s.lock.Lock()
+			reject := s.rejectResume
+			s.lock.Unlock()
+			if reject {
+				ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4006, "Session no longer valid"))
+				return
+			}
+
+			var resume discord.VoiceResume
+			json.Unmarshal(payload.Data, &resume)
+			ws.WriteJSON(map[string]any{"op": 8, "d": map[string]any{"heartbeat_interval": 50}})
+			ws.WriteJSON(map[string]any{"op": 9, "d": nil})
+			s.resumed <- resume
+		}
+	}
+}
+
+func TestVoiceConnection(t *testing.T) {
+	s := newFakeVoiceServer(t, true)
+	v := s.connect(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	v.RLock()
+	firstStop := v.wsStop
+	v.RUnlock()
+
+	s.lock.Lock()
+	identify, protocol := s.identify, s.protocol
+	s.lock.Unlock()
+
+	if identify == nil || identify.ServerID != "guild" || identify.UserID != "user" || identify.SessionID != "session" || identify.Token != "token" {
+		t.Fatalf("unexpected identify: %+v", identify)
+	}
+	if protocol == nil || protocol.Data == nil || protocol.Protocol != "udp" || protocol.Data.Mode != "aead_aes256_gcm_rtpsize" || protocol.Data.Address != "127.0.0.1" {
+		t.Fatalf("unexpected select protocol: %+v", protocol)
+	}
+	if v.SSRC != 7 {
+		t.Fatalf("expected ssrc 7, got %d", v.SSRC)
+	}
+
+	// the session description's key and mode are used to encrypt audio
+	if err := v.WriteOpus([]byte("opus")); err != nil {
+		t.Fatalf("error writing opus: %s", err)
+	}
+	var packet []byte
+	select {
+	case packet = <-s.packets:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for voice packet")
+	}
+
+	opus, err := s.decrypt(packet)
+	if err != nil {
+		t.Fatalf("error decrypting voice packet: %s", err)
+	}
+	if string(opus) != "opus" {
+		t.Fatalf("expected opus, got %q", opus)
+	}
+
+	var resume discord.VoiceResume
+	select {
+	case resume = <-s.resumed:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for resume")
+	}
+	if resume.ServerID != "guild" || resume.SessionID != "session" || resume.Token != "token" {
+		t.Fatalf("unexpected resume: %+v", resume)
+	}
+
+	select {
+	case <-firstStop:
+	default:
+		t.Fatal("first websocket wasn't stopped on resume")
+	}
+
+	// heartbeats continue on the resumed websocket
+	for {
+		select {
+		case conn := <-s.beats:
+			if conn == 2 {
+				return
+			}
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for heartbeat after resume")
+		}
+	}
+}
+
+func TestVoiceConnectionResumeFailed(t *testing.T) {
+	s := newFakeVoiceServer(t, true)
+	s.rejectResume = true
+	v := s.connect(t)
+	v.Lock()
+	v.reconnectDelay = time.Millisecond
+	v.Unlock()
+
+	// the connection is reopened from scratch after the resume is rejected
+	waitFor(t, "heartbeat after reconnecting", func() bool {
+		select {
+		case conn := <-s.beats:
+			return conn == 3
+		default:
+			return false
+		}
+	})
+	s.lock.Lock()
+	identifies := s.identifies
+	s.lock.Unlock()
+	if identifies != 2 {
+		t.Fatalf("expected 2 identifies, got %d", identifies)
+	}
+
+	if err := v.WriteOpus([]byte("opus")); err != nil {
+		t.Fatalf("error writing opus after reconnecting: %s", err)
+	}
+	select {
+	case packet := <-s.packets:
+		if opus, err := s.decrypt(packet); err != nil || string(opus) != "opus" {
+			t.Fatalf("expected opus after reconnecting, got %q and %v", opus, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for voice packet after reconnecting")
+	}
+}
+
+func TestVoiceConnectionReconnectGivesUp(t *testing.T) {
+	s := newFakeVoiceServer(t, true)
+	s.rejectResume = true
+	s.maxIdentifies = 1
+	v := s.connect(t)
+	v.Lock()
+	v.reconnectDelay = time.Millisecond
+	v.Unlock()
+
+	waitFor(t, "disconnect after failed reconnects", func() bool {
+		v.RLock()
+		defer v.RUnlock()
+		select {
+		case <-v.closed:
+			return v.ws == nil && v.udp == nil
+		default:
+			return false
+		}
+	})
+
+	s.lock.Lock()
+	identifies := s.identifies
+	s.lock.Unlock()
+	if identifies != 1+maxVoiceReconnectAttempts {
+		t.Fatalf("expected %d identifies, got %d", 1+maxVoiceReconnectAttempts, identifies)
+	}
+}
@@ -0,0 +1,318 @@
+package eventide
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+const (
+	// Number of out of order packets held for a user before missing packets are skipped as lost
+	jitterBufferDepth = 5
+
+	// Number of packets buffered on a user's channel before further packets are dropped
+	voiceReceiveBuffer = 64
+)
+
+// An Opus frame received from a user in the voice channel
+type VoicePacket struct {
+	// The user the audio is from
+	UserID string
+
+	// SSRC of the user's audio
+	SSRC uint32
+
+	// RTP sequence number of the packet
+	Sequence uint16
+
+	// RTP timestamp of the frame, in 48kHz samples
+	Timestamp uint32
+
+	// When the packet was received
+	Received time.Time
+
+	// Number of packets lost immediately before this one
+	Lost int
+
+	// The Opus frame
+	Opus []byte
+}
+
+// Packet counts for a user's received audio
+type VoiceReceiveStats struct {
+	// Packets delivered on the user's channel
+	Received int
+
+	// Packets that never arrived
+	Lost int
+
+	// Packets that arrived after later packets had already been delivered
+	Late int
+
+	// Packets dropped because the user's channel was full
+	Dropped int
+}
+
+type voiceReceiver struct {
+	packets chan *VoicePacket
+	ssrc    uint32
+	buffer  *jitterBuffer
+	stats   VoiceReceiveStats
+}
+
+// Returns a channel of the Opus frames received from a user, in sequence order. The channel is closed when the voice
+// connection is disconnected
+func (v *VoiceConnection) Receive(userID string) <-chan *VoicePacket {
+	v.receiveLock.Lock()
+	defer v.receiveLock.Unlock()
+
+	if v.receivers == nil {
+		v.receivers = make(map[string]*voiceReceiver)
+	}
+	r, ok := v.receivers[userID]
+	if !ok {
+		r = &voiceReceiver{
+			packets: make(chan *VoicePacket, voiceReceiveBuffer),
+			buffer:  newJitterBuffer(jitterBufferDepth),
+		}
+		v.receivers[userID] = r
+	}
+
+	return r.packets
+}
+
+// Returns the packet counts for a user's received audio
+func (v *VoiceConnection) ReceiveStats(userID string) VoiceReceiveStats {
+	v.receiveLock.Lock()
+	defer v.receiveLock.Unlock()
+
+	if r, ok := v.receivers[userID]; ok {
+		return r.stats
+	}
+	return VoiceReceiveStats{}
+}
+
+// Maps SSRCs to users from speaking payloads, and forgets users who disconnect
+func (v *VoiceConnection) handleSpeaking(payload discord.GatewayPayload[json.RawMessage]) {
+	v.receiveLock.Lock()
+	defer v.receiveLock.Unlock()
+
+	if v.ssrcUsers == nil {
+		v.ssrcUsers = make(map[uint32]string)
+	}
+
+	switch payload.Op {
+	case 5:
+		var speaking discord.VoiceSpeaking
+		if err := json.Unmarshal(payload.Data, &speaking); err != nil {
+			v.log(LogWarn, "error decoding speaking payload: %s", err)
+			return
+		}
+		if speaking.UserID != "" {
+			v.ssrcUsers[speaking.SSRC] = speaking.UserID
+		}
+	case 13:
+		var disconnect discord.VoiceClientDisconnect
+		if err := json.Unmarshal(payload.Data, &disconnect); err != nil {
+			v.log(LogWarn, "error decoding client disconnect payload: %s", err)
+			return
+		}
+		for ssrc, userID := range v.ssrcUsers {
+			if userID == disconnect.UserID {
+				delete(v.ssrcUsers, ssrc)
+			}
+		}
+		if r, ok := v.receivers[disconnect.UserID]; ok {
+			for _, p := range r.buffer.flush() {
+				r.deliver(p)
+			}
+		}
+	}
+}
+
+func (v *VoiceConnection) receiveLoop(udp *net.UDPConn, aead cipher.AEAD, closed chan struct{}) {
+	buf := make([]byte, 1500)
+	for {
+		n, err := udp.Read(buf)
+		if err != nil {
+			select {
+			case <-closed:
+			default:
+				v.log(LogWarn, "error reading voice packet: %s", err)
+			}
+			return
+		}
+
+		packet, err := openVoicePacket(aead, buf[:n])
+		if err != nil {
+			// RTCP and other non-audio packets are ignored
+			continue
+		}
+		packet.Received = time.Now()
+
+		v.receiveLock.Lock()
+		userID, ok := v.ssrcUsers[packet.SSRC]
+		if r, subscribed := v.receivers[userID]; ok && subscribed {
+			packet.UserID = userID
+			if r.ssrc != packet.SSRC {
+				// the user has reconnected, so sequence numbers start again
+				for _, p := range r.buffer.flush() {
+					r.deliver(p)
+				}
+				r.ssrc = packet.SSRC
+			}
+			for _, p := range r.buffer.push(packet, &r.stats) {
+				r.deliver(p)
+			}
+		}
+		v.receiveLock.Unlock()
+	}
+}
+
+func (r *voiceReceiver) deliver(p *VoicePacket) {
+	select {
+	case r.packets <- p:
+		r.stats.Received++
+		r.stats.Lost += p.Lost
+	default:
+		r.stats.Dropped++
+	}
+}
+
+// Closes the channels of all receiving users
+func (v *VoiceConnection) closeReceivers() {
+	v.receiveLock.Lock()
+	defer v.receiveLock.Unlock()
+
+	for userID, r := range v.receivers {
+		close(r.packets)
+		delete(v.receivers, userID)
+	}
+}
+
+var errNotAudio = errors.New("packet isn't an audio packet")
+
+// Decrypts an RTP packet, returning its header fields and Opus frame
+func openVoicePacket(aead cipher.AEAD, packet []byte) (*VoicePacket, error) {
+	if len(packet) < rtpHeaderSize+voiceNonceSize || packet[0]&0xC0 != rtpVersion || packet[1]&0x7F != rtpOpusType {
+		return nil, errNotAudio
+	}
+
+	headerSize := rtpHeaderSize + 4*int(packet[0]&0x0F)
+	extension := packet[0]&0x10 != 0
+	extensionSize := 0
+	if extension {
+		// only the extension's header is unencrypted, its body is at the start of the decrypted payload
+		if len(packet) < headerSize+4 {
+			return nil, errNotAudio
+		}
+		extensionSize = 4 * int(binary.BigEndian.Uint16(packet[headerSize+2:headerSize+4]))
+		headerSize += 4
+	}
+	if len(packet) < headerSize+aead.Overhead()+voiceNonceSize {
+		return nil, errNotAudio
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	copy(nonce, packet[len(packet)-voiceNonceSize:])
+
+	plaintext, err := aead.Open(nil, nonce, packet[headerSize:len(packet)-voiceNonceSize], packet[:headerSize])
+	if err != nil {
+		return nil, err
+	}
+
+	if packet[0]&0x20 != 0 && len(plaintext) > 0 {
+		// padding, the last byte holds its length
+		padding := int(plaintext[len(plaintext)-1])
+		if padding > len(plaintext) {
+			return nil, errNotAudio
+		}
+		plaintext = plaintext[:len(plaintext)-padding]
+	}
+	if len(plaintext) < extensionSize {
+		return nil, errNotAudio
+	}
+
+	return &VoicePacket{
+		SSRC:      binary.BigEndian.Uint32(packet[8:12]),
+		Sequence:  binary.BigEndian.Uint16(packet[2:4]),
+		Timestamp: binary.BigEndian.Uint32(packet[4:8]),
+		Opus:      plaintext[extensionSize:],
+	}, nil
+}
+
+// Reorders packets by sequence number, holding out of order packets until the missing ones arrive or too many are held
+type jitterBuffer struct {
+	depth   int
+	started bool
+	next    uint16
+	held    map[uint16]*VoicePacket
+}
+
+func newJitterBuffer(depth int) *jitterBuffer {
+	return &jitterBuffer{
+		depth: depth,
+		held:  make(map[uint16]*VoicePacket),
+	}
+}
+
+// Adds a packet, returning the packets that are ready to be delivered in order
+func (j *jitterBuffer) push(p *VoicePacket, stats *VoiceReceiveStats) []*VoicePacket {
+	if !j.started {
+		j.started = true
+		j.next = p.Sequence
+	}
+
+	// sequence numbers wrap, so they're compared by their signed distance
+	distance := int16(p.Sequence - j.next)
+	if distance < 0 {
+		stats.Late++
+		return nil
+	}
+	j.held[p.Sequence] = p
+
+	var ready []*VoicePacket
+	lost := 0
+	for len(j.held) > 0 {
+		if next, ok := j.held[j.next]; ok {
+			delete(j.held, j.next)
+			next.Lost = lost
+			lost = 0
+			ready = append(ready, next)
+			j.next++
+			continue
+		}
+		if len(j.held) <= j.depth {
+			break
+		}
+		// give up on the missing packet
+		lost++
+		j.next++
+	}
+
+	return ready
+}
+
+// Returns all held packets in order, skipping any that are missing
+func (j *jitterBuffer) flush() []*VoicePacket {
+	var ready []*VoicePacket
+	lost := 0
+	for len(j.held) > 0 {
+		if next, ok := j.held[j.next]; ok {
+			delete(j.held, j.next)
+			next.Lost = lost
+			lost = 0
+			ready = append(ready, next)
+		} else {
+			lost++
+		}
+		j.next++
+	}
+	j.started = false
+	return ready
+}
@@ -0,0 +1,297 @@
+package eventide
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+func newTestAEAD(t *testing.T) cipher.AEAD {
+	block, err := aes.NewCipher(make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return aead
+}
+
+// Builds an encrypted packet the way Discord sends them, with the RTP header and extension header unencrypted
+func sealTestPacket(aead cipher.AEAD, header []byte, plaintext []byte) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint32(nonce, 1)
+
+	packet := append([]byte(nil), header...)
+	packet = aead.Seal(packet, nonce, plaintext, header)
+	return append(packet, nonce[:voiceNonceSize]...)
+}
+
+func TestOpenVoicePacket(t *testing.T) {
+	aead := newTestAEAD(t)
+	opus := []byte{0xF8, 0xFF, 0xFE}
+
+	header := make([]byte, rtpHeaderSize)
+	header[1] = rtpOpusType
+	binary.BigEndian.PutUint16(header[2:], 42)
+	binary.BigEndian.PutUint32(header[4:], 960)
+	binary.BigEndian.PutUint32(header[8:], 1234)
+
+	tests := []struct {
+		name      string
+		flags     byte
+		extension []byte
+		padding   int
+	}{
+		{name: "plain"},
+		{name: "extension", flags: 0x10, extension: []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+		{name: "padding", flags: 0x20, padding: 3},
+		{name: "extension and padding", flags: 0x30, extension: []byte{1, 2, 3, 4}, padding: 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := append([]byte(nil), header...)
+			h[0] = rtpVersion | test.flags
+			if test.extension != nil {
+				// profile and length in 32 bit words are unencrypted, the extension body is encrypted
+				h = append(h, 0xBE, 0xDE, 0, 0)
+				binary.BigEndian.PutUint16(h[rtpHeaderSize+2:], uint16(len(test.extension)/4))
+			}
+
+			plaintext := append(append([]byte(nil), test.extension...), opus...)
+			if test.padding > 0 {
+				plaintext = append(plaintext, make([]byte, test.padding)...)
+				plaintext[len(plaintext)-1] = byte(test.padding)
+			}
+
+			p, err := openVoicePacket(aead, sealTestPacket(aead, h, plaintext))
+			if err != nil {
+				t.Fatalf("error opening packet: %s", err)
+			}
+			if !bytes.Equal(p.Opus, opus) {
+				t.Errorf("expected opus %v, got %v", opus, p.Opus)
+			}
+			if p.Sequence != 42 || p.Timestamp != 960 || p.SSRC != 1234 {
+				t.Errorf("unexpected header fields: sequence %d, timestamp %d, ssrc %d", p.Sequence, p.Timestamp, p.SSRC)
+			}
+		})
+	}
+
+	t.Run("not audio", func(t *testing.T) {
+		h := append([]byte(nil), header...)
+		h[0] = rtpVersion
+		h[1] = 200
+		if _, err := openVoicePacket(aead, sealTestPacket(aead, h, opus)); err != errNotAudio {
+			t.Errorf("expected errNotAudio, got %v", err)
+		}
+	})
+}
+
+// Packets in the aead_aes256_gcm_rtpsize wire format encrypted with the key 00 01 .. 1f. The RTP header, CSRCs and
+// extension header are sent in the clear as the additional data, the extension body, Opus frame and padding are
+// encrypted, and the 4 byte nonce counter follows the tag
+var voicePacketFixtures = []struct {
+	name      string
+	packet    string
+	ssrc      uint32
+	sequence  uint16
+	timestamp uint32
+	opus      string
+}{
+	{
+		name:      "audio level extension",
+		packet:    "9078123400003c000001e240bede000154c9cdaa74516b947ed9c58ff6b97a1b55bd6ab080b31515e5477700000001",
+		ssrc:      123456,
+		sequence:  0x1234,
+		timestamp: 0x3c00,
+		opus:      "780b9f3c21e5a0",
+	},
+	{
+		name:      "csrc",
+		packet:    "8178ffffdeadbeef000000070000000b7af00a69c659a711bbd8a718ae62f6e0337d490000002a",
+		ssrc:      7,
+		sequence:  0xffff,
+		timestamp: 0xdeadbeef,
+		opus:      "fcfffe",
+	},
+	{
+		name:      "two word extension and padding",
+		packet:    "b07800010000096000000009bede00028970aacf1a2c15a74fcced184177e01d92150f3778e0e83af2532d107e6affffffff",
+		ssrc:      9,
+		sequence:  1,
+		timestamp: 0x960,
+		opus:      "f8fffe",
+	},
+}
+
+func TestOpenVoicePacketFixtures(t *testing.T) {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fixture := range voicePacketFixtures {
+		t.Run(fixture.name, func(t *testing.T) {
+			packet, _ := hex.DecodeString(fixture.packet)
+			p, err := openVoicePacket(aead, packet)
+			if err != nil {
+				t.Fatalf("error opening packet: %s", err)
+			}
+			if opus := hex.EncodeToString(p.Opus); opus != fixture.opus {
+				t.Errorf("expected opus %s, got %s", fixture.opus, opus)
+			}
+			if p.SSRC != fixture.ssrc || p.Sequence != fixture.sequence || p.Timestamp != fixture.timestamp {
+				t.Errorf("unexpected header fields: ssrc %d, sequence %d, timestamp %d", p.SSRC, p.Sequence, p.Timestamp)
+			}
+		})
+	}
+}
+
+func speakingPayload(t *testing.T, op int, data any) discord.GatewayPayload[json.RawMessage] {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return discord.GatewayPayload[json.RawMessage]{Op: op, Data: raw}
+}
+
+func TestHandleSpeaking(t *testing.T) {
+	v := NewVoiceConnection("bot", "session", &discord.VoiceServerUpdateEvent{GuildID: "guild"})
+	packets := v.Receive("user")
+
+	v.handleSpeaking(speakingPayload(t, 5, discord.VoiceSpeaking{SSRC: 10, UserID: "user"}))
+	v.handleSpeaking(speakingPayload(t, 5, discord.VoiceSpeaking{SSRC: 20, UserID: "other"}))
+	if v.ssrcUsers[10] != "user" || v.ssrcUsers[20] != "other" {
+		t.Fatalf("unexpected ssrc users after speaking: %v", v.ssrcUsers)
+	}
+
+	// packets held by the jitter buffer are delivered when the user disconnects
+	r := v.receivers["user"]
+	r.buffer.push(&VoicePacket{Sequence: 1}, &r.stats)
+	r.buffer.push(&VoicePacket{Sequence: 3}, &r.stats)
+
+	v.handleSpeaking(speakingPayload(t, 13, discord.VoiceClientDisconnect{UserID: "user"}))
+	if _, ok := v.ssrcUsers[10]; ok {
+		t.Fatal("ssrc 10 wasn't forgotten after the user disconnected")
+	}
+	if v.ssrcUsers[20] != "other" {
+		t.Fatal("ssrc 20 was forgotten after another user disconnected")
+	}
+
+	select {
+	case p := <-packets:
+		if p.Sequence != 3 || p.Lost != 1 {
+			t.Fatalf("expected packet 3 after 1 lost, got packet %d after %d lost", p.Sequence, p.Lost)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("held packet wasn't delivered on disconnect")
+	}
+	if stats := v.ReceiveStats("user"); stats.Received != 1 || stats.Lost != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestJitterBuffer(t *testing.T) {
+	tests := []struct {
+		name  string
+		push  []uint16
+		ready [][]uint16
+		lost  map[uint16]int
+		late  int
+	}{
+		{
+			name:  "in order",
+			push:  []uint16{1, 2, 3},
+			ready: [][]uint16{{1}, {2}, {3}},
+		},
+		{
+			name:  "reordered",
+			push:  []uint16{1, 3, 2, 4},
+			ready: [][]uint16{{1}, nil, {2, 3}, {4}},
+		},
+		{
+			name:  "lost",
+			push:  []uint16{1, 3, 4, 5},
+			ready: [][]uint16{{1}, nil, nil, {3, 4, 5}},
+			lost:  map[uint16]int{3: 1},
+		},
+		{
+			name:  "late",
+			push:  []uint16{5, 6, 4, 8, 9, 10, 7},
+			ready: [][]uint16{{5}, {6}, nil, nil, nil, {8, 9, 10}, nil},
+			lost:  map[uint16]int{8: 1},
+			late:  2,
+		},
+		{
+			name:  "wraparound",
+			push:  []uint16{65534, 65535, 1, 0, 2},
+			ready: [][]uint16{{65534}, {65535}, nil, {0, 1}, {2}},
+		},
+		{
+			name:  "lost across wraparound",
+			push:  []uint16{65535, 1, 2, 3},
+			ready: [][]uint16{{65535}, nil, nil, {1, 2, 3}},
+			lost:  map[uint16]int{1: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			j := newJitterBuffer(2)
+			var stats VoiceReceiveStats
+
+			for i, seq := range test.push {
+				var got []uint16
+				for _, p := range j.push(&VoicePacket{Sequence: seq}, &stats) {
+					got = append(got, p.Sequence)
+					if p.Lost != test.lost[p.Sequence] {
+						t.Errorf("expected %d lost before packet %d, got %d", test.lost[p.Sequence], p.Sequence, p.Lost)
+					}
+				}
+				if !reflect.DeepEqual(got, test.ready[i]) {
+					t.Fatalf("after pushing %d expected %v ready, got %v", seq, test.ready[i], got)
+				}
+			}
+			if stats.Late != test.late {
+				t.Errorf("expected %d late, got %d", test.late, stats.Late)
+			}
+		})
+	}
+}
+
+func TestJitterBufferFlush(t *testing.T) {
+	j := newJitterBuffer(5)
+	var stats VoiceReceiveStats
+
+	j.push(&VoicePacket{Sequence: 65535}, &stats)
+	j.push(&VoicePacket{Sequence: 1}, &stats)
+	j.push(&VoicePacket{Sequence: 4}, &stats)
+
+	flushed := j.flush()
+	if len(flushed) != 2 || flushed[0].Sequence != 1 || flushed[0].Lost != 1 || flushed[1].Sequence != 4 || flushed[1].Lost != 2 {
+		t.Fatalf("unexpected flushed packets: %+v", flushed)
+	}
+
+	// the next packet starts a new sequence
+	if ready := j.push(&VoicePacket{Sequence: 100}, &stats); len(ready) != 1 || ready[0].Sequence != 100 {
+		t.Fatalf("expected packet 100 after flushing, got %+v", ready)
+	}
+}
@@ -0,0 +1,103 @@
+package eventide
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+)
+
+const (
+	// The encryption mode used for voice packets, the only supported mode that only requires the standard library
+	voiceModeAES256GCM = "aead_aes256_gcm_rtpsize"
+
+	// Sample rate of Opus audio sent to Discord
+	opusSampleRate = 48000
+
+	rtpHeaderSize = 12
+	rtpVersion    = 0x80
+	rtpOpusType   = 0x78
+
+	// Size of the incrementing nonce appended to each encrypted voice packet
+	voiceNonceSize = 4
+)
+
+func selectVoiceMode(modes []string) (string, error) {
+	for _, mode := range modes {
+		if mode == voiceModeAES256GCM {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("voice server doesn't support a compatible encryption mode, supported modes are %v", modes)
+}
+
+func newVoiceCipher(mode string, key [32]byte) (cipher.AEAD, error) {
+	if mode != voiceModeAES256GCM {
+		return nil, fmt.Errorf("unsupported voice encryption mode %s", mode)
+	}
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Discovers the client's external address and port as seen by the voice server
+// https://discord.com/developers/docs/topics/voice-connections#ip-discovery
+func discoverIP(udp *net.UDPConn, ssrc uint32) (string, int, error) {
+	request := make([]byte, 74)
+	binary.BigEndian.PutUint16(request[0:2], 1)
+	binary.BigEndian.PutUint16(request[2:4], 70)
+	binary.BigEndian.PutUint32(request[4:8], ssrc)
+
+	if _, err := udp.Write(request); err != nil {
+		return "", 0, err
+	}
+
+	response := make([]byte, 74)
+	n, err := udp.Read(response)
+	if err != nil {
+		return "", 0, err
+	}
+	if n < 74 || binary.BigEndian.Uint16(response[0:2]) != 2 {
+		return "", 0, errors.New("invalid ip discovery response")
+	}
+
+	address := response[8:72]
+	if i := bytes.IndexByte(address, 0); i != -1 {
+		address = address[:i]
+	}
+	port := binary.BigEndian.Uint16(response[72:74])
+
+	return string(address), int(port), nil
+}
+
+func rtpHeader(sequence uint16, timestamp uint32, ssrc uint32) []byte {
+	header := make([]byte, rtpHeaderSize)
+	header[0] = rtpVersion
+	header[1] = rtpOpusType
+	binary.BigEndian.PutUint16(header[2:4], sequence)
+	binary.BigEndian.PutUint32(header[4:8], timestamp)
+	binary.BigEndian.PutUint32(header[8:12], ssrc)
+	return header
+}
+
+// Encrypts an Opus frame into an RTP packet, authenticating the header and appending the nonce
+func sealVoicePacket(aead cipher.AEAD, header []byte, frame []byte, nonce uint32) []byte {
+	var suffix [voiceNonceSize]byte
+	binary.BigEndian.PutUint32(suffix[:], nonce)
+
+	// the nonce is padded with zeroes to the cipher's nonce size
+	full := make([]byte, aead.NonceSize())
+	copy(full, suffix[:])
+
+	packet := make([]byte, len(header), len(header)+len(frame)+aead.Overhead()+voiceNonceSize)
+	copy(packet, header)
+	packet = aead.Seal(packet, full, frame, header)
+
+	return append(packet, suffix[:]...)
+}
@@ -0,0 +1,81 @@
+package eventide
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/thefakequake/eventide/discord"
+)
+
+// A lightweight client for a single webhook, authenticated by the webhook's token rather than a bot token
+type WebhookClient struct {
+	ID    string
+	Token string
+
+	client *Client
+}
+
+// Creates a webhook client from a webhook ID and token
+func NewWebhookClient(webhookID string, token string) *WebhookClient {
+	c := &Client{}
+	c.configureREST(ClientConfig{})
+
+	return &WebhookClient{
+		ID:     webhookID,
+		Token:  token,
+		client: c,
+	}
+}
+
+// Creates a webhook client from a webhook URL, such as https://discord.com/api/webhooks/<id>/<token>
+func NewWebhookClientFromURL(webhookURL string) (*WebhookClient, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, part := range parts {
+		if part == "webhooks" && i+2 < len(parts) {
+			return NewWebhookClient(parts[i+1], parts[i+2]), nil
+		}
+	}
+
+	return nil, errors.New("invalid webhook url")
+}
+
+// Fetches the webhook
+func (w *WebhookClient) Get() (*discord.Webhook, error) {
+	return w.client.GetWebhookWithToken(w.ID, w.Token)
+}
+
+// Modifies the webhook's default name or avatar
+func (w *WebhookClient) Modify(params *discord.ModifyWebhook) (*discord.Webhook, error) {
+	return w.client.ModifyWebhookWithToken(w.ID, w.Token, params)
+}
+
+// Deletes the webhook
+func (w *WebhookClient) Delete() error {
+	return w.client.DeleteWebhookWithToken(w.ID, w.Token)
+}
+
+// Executes the webhook, the returned message is nil unless query.Wait is true
+func (w *WebhookClient) Execute(params *discord.ExecuteWebhook, query *discord.ExecuteWebhookQuery) (*discord.Message, error) {
+	return w.client.ExecuteWebhook(w.ID, w.Token, params, query)
+}
+
+// Fetches a message previously sent by the webhook
+func (w *WebhookClient) GetMessage(messageID string, threadID string) (*discord.Message, error) {
+	return w.client.GetWebhookMessage(w.ID, w.Token, messageID, threadID)
+}
+
+// Edits a message previously sent by the webhook
+func (w *WebhookClient) EditMessage(messageID string, threadID string, params *discord.EditWebhookMessage) (*discord.Message, error) {
+	return w.client.EditWebhookMessage(w.ID, w.Token, messageID, threadID, params)
+}
+
+// Deletes a message previously sent by the webhook
+func (w *WebhookClient) DeleteMessage(messageID string, threadID string) error {
+	return w.client.DeleteWebhookMessage(w.ID, w.Token, messageID, threadID)
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -21,6 +23,9 @@ func (c *Client) Connect() error {
 		return errors.New("websocket connection is already open")
 	}
 
+	if c.gatewayErr != nil {
+		return fmt.Errorf("invalid gateway url: %s", c.gatewayErr)
+	}
 	if c.gateway == "" {
 		c.gateway, err = c.GetGateway()
 		if err != nil {
@@ -38,13 +43,16 @@ func (c *Client) Connect() error {
 	c.Lock()
 	defer c.Unlock()
 
-	c.ws, _, err = websocket.DefaultDialer.Dial(c.gateway, nil)
+	c.ws, _, err = c.dialer.Dial(c.gateway, c.gatewayHeaders)
 	if err != nil {
 		return err
 	}
 
 	c.log(LogInfo, "established connection with gateway")
 
+	// the send rate limit is per connection
+	c.sendLimiter.reset()
+
 	var payload discord.GatewayPayload[discord.Hello]
 
 	if err = c.ws.ReadJSON(&payload); err != nil {
@@ -72,7 +80,7 @@ func (c *Client) Connect() error {
 			},
 		}
 
-		if err = c.ws.WriteJSON(&resumePayload); err != nil {
+		if err = c.writePayload(&resumePayload, true); err != nil {
 			return fmt.Errorf("error sending resume payload: %s", err)
 		}
 		c.log(LogInfo, "sent resume payload")
@@ -99,6 +107,10 @@ func (c *Client) Connect() error {
 }
 
 func (c *Client) identify() error {
+	c.presenceLock.RLock()
+	presence := c.presence
+	c.presenceLock.RUnlock()
+
 	payload := discord.GatewayPayload[discord.Identify]{
 		Op: 2,
 		Data: discord.Identify{
@@ -106,13 +118,53 @@ func (c *Client) identify() error {
 			Intents:    c.intents,
 			Properties: c.identifyProperties,
 			Compress:   c.compress,
+			Presence:   presence,
 		},
 	}
+	return c.writePayload(&payload, true)
+}
+
+// Adds the API version and encoding to a gateway URL if it doesn't have them
+func gatewayURL(gateway string, version string) (string, error) {
+	u, err := url.Parse(gateway)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "ws" && u.Scheme != "wss" {
+		return "", fmt.Errorf("scheme must be ws or wss, got %q", u.Scheme)
+	}
+
+	q := u.Query()
+	if q.Get("v") == "" {
+		q.Set("v", version)
+	}
+	if q.Get("encoding") == "" {
+		q.Set("encoding", "json")
+	}
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// Sends a payload over the gateway websocket, waiting for the send rate limit. Returns ErrGatewaySendQueueFull if too
+// many sends are already waiting
+func (c *Client) sendPayload(payload any) error {
+	return c.writePayload(payload, false)
+}
+
+// Sends a payload over the gateway websocket, reserved payloads can use the capacity kept for heartbeats
+func (c *Client) writePayload(payload any, reserved bool) error {
+	if err := c.sendLimiter.wait(reserved); err != nil {
+		return err
+	}
+
 	c.wsLock.Lock()
-	err := c.ws.WriteJSON(&payload)
-	c.wsLock.Unlock()
+	defer c.wsLock.Unlock()
 
-	return err
+	if c.ws == nil {
+		return errors.New("gateway websocket is not open")
+	}
+	return c.ws.WriteJSON(payload)
 }
 
 func (c *Client) listenClose() chan int {
@@ -134,13 +186,12 @@ func (c *Client) sendHeartbeat() error {
 		Data: seq,
 	}
 
-	c.wsLock.Lock()
-	err := c.ws.WriteJSON(&heartbeat)
-	c.wsLock.Unlock()
-
+	if err := c.writePayload(&heartbeat, true); err != nil {
+		return err
+	}
 	c.log(LogDebug, "sent heartbeat")
 
-	return err
+	return nil
 }
 
 func (c *Client) heartbeatLoop(interval time.Duration) {
@@ -262,6 +313,9 @@ func (c *Client) Reconnect() error {
 func (c *Client) closeWebsocket(code int) error {
 	var err error
 
+	// sends waiting for the rate limit would otherwise hold up the close or the next connection
+	c.sendLimiter.close()
+
 	c.Lock()
 	defer c.Unlock()
 
@@ -291,3 +345,122 @@ func (c *Client) closeWebsocket(code int) error {
 
 	return err
 }
+
+const (
+	// Number of payloads that can be sent over a gateway connection per minute
+	gatewaySendLimit = 120
+
+	// Payloads kept back for heartbeats, identifies and resumes
+	gatewaySendReserve = 5
+
+	// Number of sends that can wait for the rate limit before further sends fail
+	gatewaySendQueueSize = 60
+)
+
+// Returned when sending a gateway payload while too many sends are waiting for the rate limit
+var ErrGatewaySendQueueFull = errors.New("gateway send queue is full")
+
+// Returned by sends that were waiting for the rate limit when the gateway connection closed
+var ErrGatewayClosed = errors.New("gateway connection closed")
+
+// Limits the payloads sent over a gateway connection with a token bucket. Half of the limit can be sent at once and
+// the rest is refilled over the minute, so no more than the limit is sent in any minute
+// https://discord.com/developers/docs/topics/gateway#rate-limiting
+type gatewayLimiter struct {
+	lock    sync.Mutex
+	tokens  float64
+	updated time.Time
+	queue   chan struct{}
+	// closed when the connection closes, stopping waiting sends
+	closed chan struct{}
+
+	// clock used for refilling and waiting, replaced in tests
+	now   func() time.Time
+	after func(time.Duration) <-chan time.Time
+}
+
+func newGatewayLimiter() *gatewayLimiter {
+	l := &gatewayLimiter{
+		queue: make(chan struct{}, gatewaySendQueueSize),
+		now:   time.Now,
+		after: time.After,
+	}
+	l.reset()
+	return l
+}
+
+// Fills the bucket, for a new connection
+func (l *gatewayLimiter) reset() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.closed != nil {
+		// sends still waiting on the previous connection give up
+		select {
+		case <-l.closed:
+		default:
+			close(l.closed)
+		}
+	}
+	l.tokens = gatewaySendLimit / 2
+	l.updated = l.now()
+	l.closed = make(chan struct{})
+}
+
+// Stops the sends waiting for the connection, which return ErrGatewayClosed
+func (l *gatewayLimiter) close() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	select {
+	case <-l.closed:
+	default:
+		close(l.closed)
+	}
+}
+
+// Waits until a payload can be sent. Payloads that aren't reserved wait in the queue and leave the reserved capacity
+// alone, reserved payloads skip the queue
+func (l *gatewayLimiter) wait(reserved bool) error {
+	floor := float64(gatewaySendReserve)
+	if reserved {
+		floor = 0
+	} else {
+		select {
+		case l.queue <- struct{}{}:
+			defer func() { <-l.queue }()
+		default:
+			return ErrGatewaySendQueueFull
+		}
+	}
+
+	for {
+		l.lock.Lock()
+		closed := l.closed
+		now := l.now()
+		l.tokens += now.Sub(l.updated).Seconds() * gatewaySendLimit / 2 / 60
+		if l.tokens > gatewaySendLimit/2 {
+			l.tokens = gatewaySendLimit / 2
+		}
+		l.updated = now
+
+		if l.tokens >= floor+1 {
+			l.tokens--
+			l.lock.Unlock()
+			return nil
+		}
+		delay := time.Duration((floor + 1 - l.tokens) * 60 / (gatewaySendLimit / 2) * float64(time.Second))
+		l.lock.Unlock()
+
+		select {
+		case <-l.after(delay):
+		case <-closed:
+			return ErrGatewayClosed
+		}
+	}
+}
+
+// Returns the number of sends waiting for the gateway rate limit, which callers can use to back off
+func (c *Client) GatewaySendQueue() int {
+	return len(c.sendLimiter.queue)
+}
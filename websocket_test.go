@@ -0,0 +1,117 @@
+package eventide
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// A clock that only moves when waited on, so rate limits can be tested without sleeping
+type fakeClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+
+	fired := make(chan time.Time, 1)
+	fired <- c.now
+	return fired
+}
+
+func newTestGatewayLimiter() (*gatewayLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l := newGatewayLimiter()
+	l.now = clock.Now
+	l.after = clock.After
+	l.reset()
+	return l, clock
+}
+
+func TestGatewayLimiterLimit(t *testing.T) {
+	l, clock := newTestGatewayLimiter()
+
+	var sent []time.Time
+	for i := 0; i < 400; i++ {
+		if err := l.wait(i%10 == 0); err != nil {
+			t.Fatalf("error waiting for send %d: %s", i, err)
+		}
+		sent = append(sent, clock.Now())
+	}
+
+	// no more than the limit are sent in any minute
+	for i := 0; i+gatewaySendLimit < len(sent); i++ {
+		if window := sent[i+gatewaySendLimit].Sub(sent[i]); window < time.Minute {
+			t.Fatalf("sends %d to %d were within %s", i, i+gatewaySendLimit, window)
+		}
+	}
+}
+
+func TestGatewayLimiterReserve(t *testing.T) {
+	l, clock := newTestGatewayLimiter()
+	start := clock.Now()
+
+	// half of the limit can be sent at once, except for the reserve
+	for i := 0; i < gatewaySendLimit/2-gatewaySendReserve; i++ {
+		l.wait(false)
+	}
+	if !clock.Now().Equal(start) {
+		t.Fatalf("expected the burst to be sent without waiting, waited %s", clock.Now().Sub(start))
+	}
+
+	// the reserve is still free for heartbeats
+	for i := 0; i < gatewaySendReserve; i++ {
+		l.wait(true)
+	}
+	if !clock.Now().Equal(start) {
+		t.Fatalf("expected reserved sends to use the reserve without waiting, waited %s", clock.Now().Sub(start))
+	}
+
+	l.wait(true)
+	if !clock.Now().After(start) {
+		t.Fatal("expected a reserved send to wait once the bucket is empty")
+	}
+}
+
+func TestGatewayLimiterClose(t *testing.T) {
+	l := newGatewayLimiter()
+	for i := 0; i < gatewaySendLimit/2-gatewaySendReserve; i++ {
+		l.wait(false)
+	}
+
+	waited := make(chan error, 1)
+	go func() {
+		waited <- l.wait(false)
+	}()
+
+	// the send would otherwise wait for about a second
+	time.Sleep(10 * time.Millisecond)
+	l.close()
+
+	select {
+	case err := <-waited:
+		if err != ErrGatewayClosed {
+			t.Fatalf("expected ErrGatewayClosed, got %v", err)
+		}
+	case <-time.After(200 * time.Millisecond):
+		t.Fatal("waiting send wasn't stopped when the connection closed")
+	}
+	if len(l.queue) != 0 {
+		t.Fatalf("expected the send to leave the queue, %d waiting", len(l.queue))
+	}
+
+	// a new connection can send again
+	l.reset()
+	if err := l.wait(false); err != nil {
+		t.Fatalf("expected sending after a reset to succeed, got %s", err)
+	}
+}